@@ -0,0 +1,8 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var apisCmd = &cobra.Command{
+	Use:   "apis",
+	Short: "Google APIs required by advncd",
+}
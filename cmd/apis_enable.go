@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpcrm"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpserviceusage"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var apisEnableCmd = &cobra.Command{
+	Use:   "enable [SERVICE...]",
+	Short: "Enable the Google APIs advncd needs in the default project",
+	Long: `Enable Google APIs in the default project.
+
+Without arguments, enables whichever of the required APIs are disabled:
+  ` + strings.Join(gcpserviceusage.RequiredNames(), "\n  "),
+	Example: `  advncd apis enable
+  advncd apis enable run cloudbuild`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := commandContext(10 * time.Minute)
+		defer cancel()
+
+		tb, err := auth.GetAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+
+		store, err := config.DefaultStore()
+		if err != nil {
+			return err
+		}
+		cfg, err := store.Load()
+		if err != nil {
+			return err
+		}
+		if cfg == nil || cfg.ProjectID == "" {
+			return ui.InputRequired("project",
+				"Run: advncd gcp project set <PROJECT_ID>")
+		}
+
+		p, err := gcpcrm.GetProject(ctx, tb.AccessToken, cfg.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		var services []string
+		if len(args) > 0 {
+			for _, a := range args {
+				services = append(services, gcpserviceusage.ServiceName(a))
+			}
+		} else {
+			fmt.Println(i18n.T("Checking required APIs..."))
+			readiness := gcpserviceusage.CheckRequired(ctx, tb.AccessToken, p.ProjectNumber)
+			// Unknown state: enabling an already enabled API is a no-op.
+			services = append(readiness.Missing, readiness.Unknown...)
+			if len(services) == 0 {
+				return ui.Render(ui.APIsEnableResult{ProjectID: cfg.ProjectID, Enabled: []string{}}, func() {
+					fmt.Println("✓ " + i18n.T("All required APIs are already enabled"))
+				})
+			}
+		}
+
+		if err := enableAPIs(ctx, tb.AccessToken, cfg.ProjectID, p.ProjectNumber, services); err != nil {
+			return err
+		}
+		return ui.Render(ui.APIsEnableResult{ProjectID: cfg.ProjectID, Enabled: services}, nil)
+	},
+}
+
+// enableAPIs runs batchEnable with progress output. Shared by `apis enable`
+// and the interactive offers in status/publish.
+func enableAPIs(ctx context.Context, accessToken, projectID, projectNumber string, services []string) error {
+	step := ui.StartStep(i18n.T("Enabling %d API(s) in %s (this can take a few minutes)", len(services), projectID))
+	for _, s := range services {
+		step.Logf("%s", s)
+	}
+	onWait := func(time.Duration) { step.Status(i18n.T("waiting")) }
+	if err := gcpserviceusage.BatchEnable(ctx, accessToken, projectNumber, services, onWait); err != nil {
+		step.Fail()
+		return err
+	}
+	step.Done("")
+	return nil
+}
+
+// offerEnableAPIs asks whether to enable the missing APIs now (--yes
+// answers for the user). Returns true if they were enabled.
+func offerEnableAPIs(ctx context.Context, accessToken, projectID, projectNumber string, missing []string) (bool, error) {
+	ok, err := ui.Confirm(i18n.T("Enable %d missing API(s) now?", len(missing)), false)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := enableAPIs(ctx, accessToken, projectID, projectNumber, missing); err != nil {
+		return false, err
+	}
+	return true, nil
+}
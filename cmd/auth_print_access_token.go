@@ -1,20 +1,20 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
 )
 
 var authPrintAccessTokenCmd = &cobra.Command{
 	Use:   "print-access-token",
 	Short: "Print a valid access token (dev/debug)",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := commandContext(30 * time.Second)
 		defer cancel()
 
 		tb, err := auth.GetAccessToken(ctx)
@@ -22,7 +22,7 @@ var authPrintAccessTokenCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Println(tb.AccessToken)
-		return nil
+		res := ui.AccessTokenResult{AccessToken: tb.AccessToken, ExpiresAt: tb.Expiry.UTC()}
+		return ui.Render(res, func() { fmt.Println(tb.AccessToken) })
 	},
 }
\ No newline at end of file
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/cloudbuild"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var buildsCmd = &cobra.Command{
+	Use:   "builds",
+	Short: "Cloud Builds started by advncd publish",
+}
+
+// buildsConfig returns the configured project and region; builds run in
+// the region of the image repository (see regions.List).
+func buildsConfig() (*config.Config, error) {
+	store, err := config.DefaultStore()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil || cfg.ProjectID == "" || cfg.Region == "" {
+		return nil, apperr.New(config.ErrNotSet).
+			WithMeta("config_path", store.Path).
+			WithFix("Run: advncd init")
+	}
+	return cfg, nil
+}
+
+func buildResult(b cloudbuild.BuildInfo) ui.BuildResult {
+	return ui.BuildResult{
+		ID:         b.ID,
+		Status:     b.Status,
+		CreateTime: b.CreateTime,
+		FinishTime: b.FinishTime,
+		LogURL:     b.LogURL,
+		Images:     b.Images,
+	}
+}
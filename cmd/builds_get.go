@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/cloudbuild"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var buildsGetCmd = &cobra.Command{
+	Use:               "get <BUILD_ID>",
+	Short:             "Show one build",
+	Example:           "  advncd builds get 0a1b2c3d-...",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBuilds,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := buildsConfig()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := commandContext(30 * time.Second)
+		defer cancel()
+
+		tb, err := auth.GetAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+
+		b, err := cloudbuild.GetBuild(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region, strings.TrimSpace(args[0]))
+		if err != nil {
+			return err
+		}
+
+		res := buildResult(*b)
+		return ui.Render(res, func() {
+			fmt.Println(i18n.T("build:    %s", res.ID))
+			fmt.Println(i18n.T("status:   %s", res.Status))
+			fmt.Println(i18n.T("created:  %s", res.CreateTime))
+			if res.FinishTime != "" {
+				fmt.Println(i18n.T("finished: %s", res.FinishTime))
+			}
+			for _, img := range res.Images {
+				fmt.Println(i18n.T("image:    %s", img))
+			}
+			if res.LogURL != "" {
+				fmt.Println(i18n.T("logs:     %s", res.LogURL))
+			}
+		})
+	},
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/cloudbuild"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/completion"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var buildsListLimit int
+
+var buildsListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List recent builds in the default project and region",
+	Example: "  advncd builds list\n  advncd builds list --limit 50 -o json",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := buildsConfig()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := commandContext(60 * time.Second)
+		defer cancel()
+
+		tb, err := auth.GetAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+
+		builds, err := cloudbuild.ListBuilds(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region, buildsListLimit)
+		if err != nil {
+			return err
+		}
+		completion.SaveBuilds(cfg.ProjectID, cfg.Region, builds)
+
+		res := make([]ui.BuildResult, len(builds))
+		for i, b := range builds {
+			res[i] = buildResult(b)
+		}
+		return ui.Render(res, func() {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, i18n.T("BUILD_ID\tSTATUS\tCREATED"))
+			for _, b := range res {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", b.ID, b.Status, b.CreateTime)
+			}
+			_ = w.Flush()
+		})
+	},
+}
+
+func init() {
+	buildsListCmd.Flags().IntVar(&buildsListLimit, "limit", 20, "Maximum number of builds to return (0 = no limit)")
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/cloudbuild"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/completion"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh|fish>",
+	Short: "Print a shell completion script",
+	Long: `Print a completion script for bash, zsh or fish.
+
+Project IDs, regions, Cloud Run services and build IDs are completed from a
+short-lived local cache, so Tab stays fast and works offline.`,
+	Example: `  # bash (needs bash-completion v2)
+  advncd completion bash > ~/.local/share/bash-completion/completions/advncd
+  # zsh
+  advncd completion zsh > "${fpath[1]}/_advncd"
+  # fish
+  advncd completion fish > ~/.config/fish/completions/advncd.fish`,
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// The script goes to the real stdout whatever --output says.
+		return ui.RenderAs(ui.OutputTable, nil, func() {
+			switch args[0] {
+			case "bash":
+				_ = rootCmd.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				_ = rootCmd.GenZshCompletion(os.Stdout)
+			case "fish":
+				_ = rootCmd.GenFishCompletion(os.Stdout, true)
+			}
+		})
+	},
+}
+
+// registerCompletions wires dynamic candidates into commands and flags; it
+// runs from root's init, after every command has registered its flags.
+func registerCompletions() {
+	_ = rootCmd.RegisterFlagCompletionFunc("output", fixedCompletion(ui.OutputTable, ui.OutputJSON, ui.OutputYAML))
+	_ = rootCmd.RegisterFlagCompletionFunc("error-format", fixedCompletion(ui.ErrorFormatText, ui.ErrorFormatJSON))
+	_ = rootCmd.RegisterFlagCompletionFunc("lang", fixedCompletion(i18n.Supported...))
+	_ = rootCmd.RegisterFlagCompletionFunc("dump-dir", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	})
+
+	_ = initCmd.RegisterFlagCompletionFunc("project", flagCompletion(completeProjects))
+	_ = initCmd.RegisterFlagCompletionFunc("region", flagCompletion(completeRegions))
+	_ = publishCmd.RegisterFlagCompletionFunc("name", flagCompletion(completeServices))
+	_ = publishCmd.RegisterFlagCompletionFunc("builder", fixedCompletion(cloudbuild.Builders...))
+	_ = gcpProjectListCmd.RegisterFlagCompletionFunc("format", fixedCompletion(ui.OutputTable, ui.OutputJSON, ui.OutputYAML, "id"))
+
+	gcpProjectSetCmd.ValidArgsFunction = completeProjects
+	gcpRegionSetCmd.ValidArgsFunction = completeRegions
+	errorsExplainCmd.ValidArgsFunction = completeErrorCodes
+	completeDir := func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+	publishCmd.ValidArgsFunction = completeDir
+	doctorCmd.ValidArgsFunction = completeDir
+}
+
+type completeFunc = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// flagCompletion adapts a positional completer to a flag, which is
+// completed whatever the positional args are.
+func flagCompletion(f completeFunc) completeFunc {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return f(cmd, nil, toComplete)
+	}
+}
+
+func fixedCompletion(values ...string) completeFunc {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeProjects(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completion.Projects(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeRegions(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	project, _ := completionTarget(cmd)
+	return completion.Regions(project), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeServices(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completion.Services(completionTarget(cmd)), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeBuilds(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completion.Builds(completionTarget(cmd)), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeErrorCodes(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var out []string
+	for _, e := range apperr.All() {
+		out = append(out, e.Code+"\t"+e.Title)
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionTarget is the project and region candidates are listed for:
+// the command's --project/--region if already typed, else the config.
+func completionTarget(cmd *cobra.Command) (project, region string) {
+	if store, err := config.DefaultStore(); err == nil {
+		if cfg, err := store.Peek(); err == nil && cfg != nil {
+			project, region = cfg.ProjectID, cfg.Region
+		}
+	}
+	if f := cmd.Flags().Lookup("project"); f != nil && f.Value.String() != "" {
+		project = f.Value.String()
+	}
+	if f := cmd.Flags().Lookup("region"); f != nil && f.Value.String() != "" {
+		region = f.Value.String()
+	}
+	return project, region
+}
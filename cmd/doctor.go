@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/preflight"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [path]",
+	Short: "Diagnose credentials, network, config and project readiness for publish",
+	Long: `Runs every check publish depends on plus local environment checks
+(credentials file, token refresh, clock skew, network reachability, Go module
+layout) and prints a pass/warn/fail table. Exits non-zero if any check fails.
+path is checked as publish [path] would build it (default: the current
+directory).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := commandContext(2 * time.Minute)
+		defer cancel()
+
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+
+		report := preflight.Doctor(ctx, preflight.DoctorInput{Dir: dir, Repo: publishRepo})
+
+		res := ui.DoctorResult{OK: !report.Blocked(), Checks: checkResults(report.Checks)}
+		if err := ui.Render(res, func() { printDoctor(report) }); err != nil {
+			return err
+		}
+
+		// In json/yaml mode the error still goes out, on stderr, and sets
+		// the exit code.
+		return report.ErrAs(preflight.ErrUnhealthy)
+	},
+}
+
+// checkResults converts checks for --output json|yaml.
+func checkResults(checks []preflight.Check) []ui.CheckResult {
+	out := make([]ui.CheckResult, len(checks))
+	for i, c := range checks {
+		out[i] = ui.CheckResult{ID: c.ID, Title: c.Title, Status: string(c.Status), Detail: c.Detail, Fixes: c.Fixes}
+	}
+	return out
+}
+
+// printDoctor is the human rendering: a table, then warn-level hints.
+func printDoctor(report *preflight.Report) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.T("STATUS\tCHECK\tDETAIL"))
+	for _, c := range report.Checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", strings.ToUpper(string(c.Status)), i18n.Text(c.Title), i18n.Text(c.Detail))
+	}
+	_ = w.Flush()
+
+	// Failed checks' fixes come with the error; show the rest here.
+	var hints []string
+	for _, c := range report.Checks {
+		if c.Status == preflight.Warn {
+			hints = append(hints, c.Fixes...)
+		}
+	}
+	if len(hints) > 0 {
+		fmt.Println()
+		fmt.Println(i18n.T("Suggestions:"))
+		for _, h := range hints {
+			fmt.Printf("  - %s\n", i18n.Text(h))
+		}
+	}
+
+	fmt.Println()
+	if !report.Blocked() {
+		fmt.Println("✓ " + i18n.T("All checks passed"))
+	}
+}
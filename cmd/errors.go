@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var errorsCmd = &cobra.Command{
+	Use:   "errors",
+	Short: "Browse the error code catalog",
+}
+
+var ErrUnknownCode = apperr.E("B-CLI-002", "Unknown error code",
+	apperr.WithSummary("The code is not in this version's error catalog."))
+
+// errorEntry converts a catalog entry for output, in the active language.
+func errorEntry(e apperr.Entry) ui.ErrorEntry {
+	e = i18n.Entry(e)
+	return ui.ErrorEntry{
+		Code:     e.Code,
+		Severity: string(e.Severity),
+		Title:    e.Title,
+		Message:  e.Message,
+		Summary:  e.Summary,
+		DocsHint: e.DocsHint,
+	}
+}
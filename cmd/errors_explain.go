@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var errorsExplainCmd = &cobra.Command{
+	Use:     "explain <CODE>",
+	Short:   "Explain an error code",
+	Example: "  advncd errors explain B-BILLING-002",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		code := strings.ToUpper(strings.TrimSpace(args[0]))
+		e, ok := apperr.Lookup(code)
+		if !ok {
+			ae := apperr.New(ErrUnknownCode).WithMeta("code", code)
+			if near := similarCodes(code); len(near) > 0 {
+				ae = ae.WithFix("Did you mean: " + strings.Join(near, ", ") + "?")
+			}
+			return ae.WithFix("Run: advncd errors list")
+		}
+
+		res := errorEntry(e)
+		return ui.Render(res, func() {
+			fmt.Printf("%s — %s\n", res.Code, res.Title)
+			fmt.Println(i18n.T("severity: %s", res.Severity))
+			if res.Message != res.Title {
+				fmt.Println(i18n.T("message:  %s", res.Message))
+			}
+			if res.Summary != "" {
+				fmt.Println()
+				fmt.Println(res.Summary)
+			}
+			if res.DocsHint != "" {
+				fmt.Println()
+				fmt.Println(i18n.T("docs: %s", res.DocsHint))
+			}
+		})
+	},
+}
+
+// similarCodes returns catalog codes in the same group (e.g. C-BUILD-*)
+// as code, for typos in the number part.
+func similarCodes(code string) []string {
+	group := code
+	if i := strings.LastIndex(code, "-"); i > 0 {
+		group = code[:i+1]
+	}
+	var out []string
+	for _, e := range apperr.All() {
+		if strings.HasPrefix(e.Code, group) {
+			out = append(out, e.Code)
+		}
+	}
+	sort.Strings(out)
+	if len(out) > 5 {
+		out = out[:5]
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var errorsListCmd = &cobra.Command{
+	Use:     "list [PREFIX]",
+	Short:   "List all error codes",
+	Example: "  advncd errors list\n  advncd errors list C-BUILD",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prefix := ""
+		if len(args) > 0 {
+			prefix = strings.ToUpper(strings.TrimSpace(args[0]))
+		}
+
+		entries := []ui.ErrorEntry{}
+		for _, e := range apperr.All() {
+			if strings.HasPrefix(e.Code, prefix) {
+				entries = append(entries, errorEntry(e))
+			}
+		}
+
+		return ui.Render(entries, func() {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, i18n.T("CODE\tSEVERITY\tTITLE"))
+			for _, e := range entries {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", e.Code, e.Severity, e.Title)
+			}
+			_ = w.Flush()
+		})
+	},
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var gcpCmd = &cobra.Command{
+	Use:   "gcp",
+	Short: "Google Cloud settings (project, region)",
+}
+
+var gcpProjectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Manage the default GCP project",
+}
+
+var gcpRegionCmd = &cobra.Command{
+	Use:   "region",
+	Short: "Manage the default GCP region",
+}
+
+var gcpBillingCmd = &cobra.Command{
+	Use:   "billing",
+	Short: "Manage billing for the default GCP project",
+}
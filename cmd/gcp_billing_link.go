@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpbilling"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var gcpBillingLinkCmd = &cobra.Command{
+	Use:     "link <BILLING_ACCOUNT_ID>",
+	Short:   "Link a billing account to the default project",
+	Example: "  advncd gcp billing link 0X0X0X-0X0X0X-0X0X0X",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := commandContext(30 * time.Second)
+		defer cancel()
+
+		tb, err := auth.GetAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+
+		store, err := config.DefaultStore()
+		if err != nil {
+			return err
+		}
+		cfg, err := store.Load()
+		if err != nil {
+			return err
+		}
+		if cfg == nil || cfg.ProjectID == "" {
+			return ui.InputRequired("project",
+				"Run: advncd gcp project set <PROJECT_ID>")
+		}
+
+		info, err := gcpbilling.LinkBillingAccount(ctx, tb.AccessToken, cfg.ProjectID, strings.TrimSpace(args[0]))
+		if err != nil {
+			return err
+		}
+
+		res := ui.BillingLinkResult{
+			ProjectID:      cfg.ProjectID,
+			Account:        strings.TrimPrefix(info.BillingAccountName, "billingAccounts/"),
+			BillingEnabled: info.BillingEnabled,
+		}
+		return ui.Render(res, func() {
+			fmt.Println("✓ " + i18n.T("Billing account %s linked to %s", res.Account, res.ProjectID))
+		})
+	},
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpartifact"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpbilling"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpcrm"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpserviceusage"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/regions"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var (
+	projectCreateName    string
+	projectCreateFolder  string
+	projectCreateOrg     string
+	projectCreateBilling string
+	projectCreateRegion  string
+)
+
+var gcpProjectCreateCmd = &cobra.Command{
+	Use:   "create <PROJECT_ID>",
+	Short: "Create a GCP project ready for advncd publish",
+	Long: `Create a GCP project and bootstrap it for advncd:
+  1. create the project (optionally under a folder or organization)
+  2. link a billing account
+  3. enable the APIs advncd needs
+  4. create the "advncd" Artifact Registry repository
+  5. make it the active project`,
+	Example: `  advncd gcp project create shop-prod --org 123456789 --billing-account 0X0X0X-0X0X0X-0X0X0X
+  advncd gcp project create shop-dev --folder 987654321 --billing-account 0X0X0X-0X0X0X-0X0X0X --region europe-west1`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID := strings.TrimSpace(args[0])
+		if err := gcpcrm.ValidateProjectID(projectID); err != nil {
+			return err
+		}
+
+		var parent string
+		switch {
+		case projectCreateFolder != "":
+			parent = "folders/" + strings.TrimPrefix(projectCreateFolder, "folders/")
+		case projectCreateOrg != "":
+			parent = "organizations/" + strings.TrimPrefix(projectCreateOrg, "organizations/")
+		}
+
+		ctx, cancel := commandContext(15 * time.Minute)
+		defer cancel()
+
+		tb, err := auth.GetAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+
+		store, err := config.DefaultStore()
+		if err != nil {
+			return err
+		}
+		cfg, err := store.Load()
+		if err != nil {
+			return err
+		}
+		if cfg == nil {
+			cfg = &config.Config{}
+		}
+
+		region := strings.TrimSpace(projectCreateRegion)
+		if region == "" {
+			region = cfg.Region
+		}
+
+		// 1) project
+		fmt.Println(i18n.T("Creating project %s...", projectID))
+		if err := gcpcrm.CreateProject(ctx, tb.AccessToken, gcpcrm.CreateProjectRequest{
+			ProjectID:   projectID,
+			DisplayName: projectCreateName,
+			Parent:      parent,
+		}); err != nil {
+			return err
+		}
+		fmt.Println("✓ " + i18n.T("Project created"))
+
+		// Active right away, so a failure in the steps below can be retried
+		// with commands that work on the configured project.
+		cfg.ProjectID = projectID
+		if err := store.Save(*cfg); err != nil {
+			return err
+		}
+		fmt.Println("✓ " + i18n.T("Active project: %s", projectID))
+		res := ui.ProjectCreateResult{ProjectID: projectID, Parent: parent}
+
+		// 2) billing: every API below except Monitoring refuses to enable without it.
+		if projectCreateBilling == "" {
+			return ui.Render(res, func() {
+				fmt.Println()
+				fmt.Println("! " + i18n.T("No --billing-account given; skipping API enablement and repository setup."))
+				fmt.Println(i18n.T("Next:"))
+				fmt.Println("  " + i18n.T("Link billing in GCP Console → Billing, then run: advncd status"))
+			})
+		}
+		fmt.Println(i18n.T("Linking billing account..."))
+		if _, err := gcpbilling.LinkBillingAccount(ctx, tb.AccessToken, projectID, projectCreateBilling); err != nil {
+			return err
+		}
+		fmt.Println("✓ " + i18n.T("Billing linked"))
+		res.BillingAccount = projectCreateBilling
+
+		// 3) APIs
+		if err := enableAPIs(ctx, tb.AccessToken, projectID, projectID, gcpserviceusage.RequiredNames()); err != nil {
+			return err
+		}
+		res.EnabledAPIs = gcpserviceusage.RequiredNames()
+
+		// 4) Artifact Registry repo (regional)
+		if region == "" {
+			return ui.Render(res, func() {
+				fmt.Println()
+				fmt.Println("! " + i18n.T("No region set; skipping Artifact Registry repository (publish creates it on first run)."))
+				fmt.Println(i18n.T("Next:"))
+				fmt.Println("  advncd gcp region set europe-west1")
+			})
+		}
+		if available, err := regions.List(ctx, tb.AccessToken, projectID); err == nil {
+			if err := regions.Validate(region, available); err != nil {
+				return err
+			}
+		}
+		fmt.Println(i18n.T("Creating Artifact Registry repo %s in %s...", publishRepo, region))
+		if err := gcpartifact.EnsureDockerRepo(ctx, tb.AccessToken, projectID, region, publishRepo); err != nil {
+			return err
+		}
+		fmt.Println("✓ " + i18n.T("Repository ready"))
+		res.Region = region
+		res.Repository = publishRepo
+
+		if cfg.Region != region {
+			cfg.Region = region
+			if err := store.Save(*cfg); err != nil {
+				return err
+			}
+		}
+
+		return ui.Render(res, func() {
+			fmt.Println()
+			fmt.Println("✓ " + i18n.T("Project %s is ready. Next:", projectID))
+			fmt.Println("  advncd publish")
+		})
+	},
+}
+
+func init() {
+	gcpProjectCreateCmd.Flags().StringVar(&projectCreateName, "name", "", "Display name (defaults to the project ID)")
+	gcpProjectCreateCmd.Flags().StringVar(&projectCreateFolder, "folder", "", "Parent folder ID")
+	gcpProjectCreateCmd.Flags().StringVar(&projectCreateOrg, "org", "", "Parent organization ID")
+	gcpProjectCreateCmd.Flags().StringVar(&projectCreateBilling, "billing-account", "", "Billing account ID to link (XXXXXX-XXXXXX-XXXXXX)")
+	gcpProjectCreateCmd.Flags().StringVar(&projectCreateRegion, "region", "", "Region for the Artifact Registry repo (defaults to configured region)")
+	gcpProjectCreateCmd.MarkFlagsMutuallyExclusive("folder", "org")
+}
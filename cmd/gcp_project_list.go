@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/completion"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpcrm"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var (
+	projectListFilter string
+	projectListFormat string
+	projectListLimit  int
+)
+
+var gcpProjectListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List accessible GCP projects",
+	Example: `  advncd gcp project list --filter shop
+  advncd gcp project list --filter parent:folders/123456 --format json
+  advncd gcp project list --format id`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// --format predates the global --output and adds "id".
+		format := projectListFormat
+		if format == "" {
+			format = ui.Output
+		}
+		if err := ui.CheckFormat(format, ui.OutputTable, ui.OutputJSON, ui.OutputYAML, "id"); err != nil {
+			return err
+		}
+
+		ctx, cancel := commandContext(60 * time.Second)
+		defer cancel()
+
+		tb, err := auth.GetAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+
+		projects, more, err := gcpcrm.SearchProjects(ctx, tb.AccessToken, gcpcrm.SearchQuery(projectListFilter), projectListLimit)
+		if err != nil {
+			return err
+		}
+		// An unfiltered list is what `gcp project set <Tab>` offers.
+		if projectListFilter == "" {
+			completion.SaveProjects(projects)
+		}
+
+		switch format {
+		case "id":
+			return ui.RenderAs(format, projects, func() {
+				for _, p := range projects {
+					fmt.Println(p.ProjectID)
+				}
+			})
+		case ui.OutputJSON, ui.OutputYAML:
+			gcpcrm.ResolvePaths(ctx, tb.AccessToken, projects)
+			if projects == nil {
+				projects = []gcpcrm.ProjectEntry{}
+			}
+			return ui.RenderAs(format, projects, nil)
+		}
+		gcpcrm.ResolvePaths(ctx, tb.AccessToken, projects)
+		if err := ui.RenderAs(format, projects, func() {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, i18n.T("PROJECT_ID\tNAME\tPARENT"))
+			for _, p := range projects {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", p.ProjectID, p.DisplayName, p.Path)
+			}
+			_ = w.Flush()
+		}); err != nil {
+			return err
+		}
+		if more {
+			fmt.Fprintln(os.Stderr, i18n.T("(showing first %d; narrow with --filter or raise --limit)", len(projects)))
+		}
+		return nil
+	},
+}
+
+func init() {
+	gcpProjectListCmd.Flags().StringVar(&projectListFilter, "filter", "", "Search text (ID/name prefix) or raw query like parent:folders/123")
+	gcpProjectListCmd.Flags().StringVar(&projectListFormat, "format", "", "Output format: table, json, yaml or id (defaults to --output)")
+	gcpProjectListCmd.Flags().IntVar(&projectListLimit, "limit", 500, "Maximum number of projects to return (0 = no limit)")
+}
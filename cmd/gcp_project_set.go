@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpcrm"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var gcpProjectSetCmd = &cobra.Command{
+	Use:     "set <PROJECT_ID>",
+	Short:   "Set the default GCP project",
+	Example: "  advncd gcp project set my-project",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := commandContext(30 * time.Second)
+		defer cancel()
+
+		projectID := strings.TrimSpace(args[0])
+
+		tb, err := auth.GetAccessToken(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Verify access now rather than at publish time.
+		if _, err := gcpcrm.GetProject(ctx, tb.AccessToken, projectID); err != nil {
+			return err
+		}
+
+		store, err := config.DefaultStore()
+		if err != nil {
+			return err
+		}
+		cfg, err := store.Load()
+		if err != nil {
+			return err
+		}
+		if cfg == nil {
+			cfg = &config.Config{}
+		}
+		cfg.ProjectID = projectID
+
+		if err := store.Save(*cfg); err != nil {
+			return err
+		}
+
+		res := ui.ConfigResult{ProjectID: cfg.ProjectID, Region: cfg.Region, ConfigPath: store.Path}
+		return ui.Render(res, func() {
+			fmt.Println("✓ " + i18n.T("Project set: %s", cfg.ProjectID))
+			if cfg.Region == "" {
+				fmt.Println(i18n.T("Next:"))
+				fmt.Println("  advncd gcp region set europe-west1")
+			}
+		})
+	},
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/regions"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
+)
+
+var gcpRegionSetCmd = &cobra.Command{
+	Use:     "set <REGION>",
+	Short:   "Set the default GCP region",
+	Example: "  advncd gcp region set europe-west1",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := commandContext(30 * time.Second)
+		defer cancel()
+
+		region := strings.ToLower(strings.TrimSpace(args[0]))
+
+		store, err := config.DefaultStore()
+		if err != nil {
+			return err
+		}
+		cfg, err := store.Load()
+		if err != nil {
+			return err
+		}
+		if cfg == nil {
+			cfg = &config.Config{}
+		}
+
+		// With a project we can check the live list; without one only the shape.
+		if cfg.ProjectID != "" {
+			tb, err := auth.GetAccessToken(ctx)
+			if err != nil {
+				return err
+			}
+			if available, err := regions.List(ctx, tb.AccessToken, cfg.ProjectID); err == nil {
+				if err := regions.Validate(region, available); err != nil {
+					return err
+				}
+			}
+		} else if !regions.LooksLikeID(region) {
+			ae := apperr.New(regions.ErrRegionInvalid).WithMeta("region", region)
+			if s := regions.Suggest(region, regions.Common, 3); len(s) > 0 {
+				ae = ae.WithFix("Did you mean: " + strings.Join(s, ", ") + "?")
+			}
+			return ae.WithFix("Example: advncd gcp region set europe-west1")
+		}
+
+		cfg.Region = region
+		if err := store.Save(*cfg); err != nil {
+			return err
+		}
+
+		res := ui.ConfigResult{ProjectID: cfg.ProjectID, Region: cfg.Region, ConfigPath: store.Path}
+		return ui.Render(res, func() {
+			fmt.Println("✓ " + i18n.T("Region set: %s", cfg.Region))
+			if cfg.ProjectID == "" {
+				fmt.Println(i18n.T("Next:"))
+				fmt.Println("  advncd gcp project set <PROJECT_ID>")
+			}
+		})
+	},
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+)
+
+var ErrTranslationsMissing = apperr.E("B-CLI-003", "Message catalog is incomplete",
+	apperr.WithSummary("Some error codes or messages have no translation for a supported language."))
+
+var i18nCmd = &cobra.Command{
+	Use:    "i18n",
+	Short:  "Message catalog maintenance",
+	Hidden: true,
+}
+
+var i18nMissingCmd = &cobra.Command{
+	Use:   "missing",
+	Short: "List catalog keys without a translation; fails if there are any",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		missing := i18n.Missing()
+		if len(missing) == 0 {
+			fmt.Printf("✓ Catalogs complete: %s\n", strings.Join(i18n.Supported, ", "))
+			return nil
+		}
+		for _, m := range missing {
+			fmt.Println(m)
+		}
+		return apperr.New(ErrTranslationsMissing).
+			WithMeta("count", fmt.Sprint(len(missing))).
+			WithFix("Add the translations to internal/i18n (one file per language).")
+	},
+}
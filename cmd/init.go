@@ -1,12 +1,8 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os"
-	"sort"
-	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +11,9 @@ import (
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpcrm"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/regions"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
 )
 
 var (
@@ -26,62 +25,66 @@ var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Select default GCP project and region",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		ctx, cancel := commandContext(60 * time.Second)
 		defer cancel()
 
+		projectID := strings.TrimSpace(initProject)
+		region := strings.TrimSpace(initRegion)
+
+		// Without a terminal we can't ask; fail before any network calls
+		// instead of hanging on a closed stdin.
+		if !ui.Interactive() && (projectID == "" || region == "") {
+			var missing []string
+			if projectID == "" {
+				missing = append(missing, "--project")
+			}
+			if region == "" {
+				missing = append(missing, "--region")
+			}
+			return ui.InputRequired(strings.Join(missing, ", "),
+				"Run: advncd init --project <PROJECT_ID> --region <REGION>",
+				"Or: advncd gcp project set <PROJECT_ID> && advncd gcp region set <REGION>")
+		}
+
 		// ensure logged in + get valid token
 		tb, err := auth.GetAccessToken(ctx)
 		if err != nil {
 			return err
 		}
 
-		projectID := strings.TrimSpace(initProject)
-		region := strings.TrimSpace(initRegion)
-
-		// If project not provided, list projects and ask user to pick
+		// If project not provided, search projects and ask user to pick
 		if projectID == "" {
-			fmt.Println("Loading GCP projects...")
-			projects, err := gcpcrm.ListProjects(ctx, tb.AccessToken)
+			projectID, err = pickProject(ctx, tb.AccessToken)
 			if err != nil {
 				return err
 			}
-			if len(projects) == 0 {
-				fmt.Println("No ACTIVE projects found for this account.")
-				fmt.Println("You can still set a project manually:")
+			if projectID == "" {
+				fmt.Println(i18n.T("No ACTIVE projects found for this account."))
+				fmt.Println(i18n.T("You can still set a project manually:"))
 				fmt.Println("  advncd init --project <project_id> --region <region>")
 				return nil
 			}
+		}
 
-			sort.Slice(projects, func(i, j int) bool {
-				return projects[i].ProjectID < projects[j].ProjectID
-			})
+		// Regions available for this project (cached). If the list can't be
+		// fetched we still let the user proceed with the common set.
+		available, regErr := regions.List(ctx, tb.AccessToken, projectID)
 
-			fmt.Println()
-			fmt.Println("Select GCP project:")
-			max := len(projects)
-			if max > 30 {
-				max = 30
-				fmt.Println("(showing first 30; use --project to set manually if needed)")
-			}
-			for i := 0; i < max; i++ {
-				p := projects[i]
-				label := p.ProjectID
-				if strings.TrimSpace(p.Name) != "" && p.Name != p.ProjectID {
-					label = fmt.Sprintf("%s (%s)", p.ProjectID, p.Name)
-				}
-				fmt.Printf("  [%d] %s\n", i+1, label)
+		// Region: if not provided, ask
+		if region == "" {
+			if regErr != nil {
+				fmt.Println("! " + i18n.T("Could not load regions for this project; showing common regions."))
+				region, err = pickRegion(regions.Common, false)
+			} else {
+				region, err = pickRegion(available, true)
 			}
-
-			choice, err := readChoice(1, max)
 			if err != nil {
 				return err
 			}
-			projectID = projects[choice-1].ProjectID
-		}
-
-		// Region: if not provided, ask
-		if region == "" {
-			region = readRegion()
+		} else if regErr == nil {
+			if err := regions.Validate(region, available); err != nil {
+				return err
+			}
 		}
 
 		store, err := config.DefaultStore()
@@ -90,20 +93,27 @@ var initCmd = &cobra.Command{
 		}
 
 		cfg := config.Config{
-			Version:   1,
+			Version:   config.CurrentVersion,
 			ProjectID: projectID,
 			Region:    region,
 		}
+		// Keep settings init doesn't ask about.
+		if prev, _ := store.Load(); prev != nil {
+			cfg.Lang = prev.Lang
+			cfg.Builder = prev.Builder
+		}
 
 		if err := store.Save(cfg); err != nil {
 			return err
 		}
 
-		fmt.Println()
-		fmt.Printf("✓ Project set: %s\n", cfg.ProjectID)
-		fmt.Printf("✓ Region set:  %s\n", cfg.Region)
-		fmt.Printf("✓ Saved config: %s\n", store.Path)
-		return nil
+		res := ui.ConfigResult{ProjectID: cfg.ProjectID, Region: cfg.Region, ConfigPath: store.Path}
+		return ui.Render(res, func() {
+			fmt.Println()
+			fmt.Println("✓ " + i18n.T("Project set: %s", cfg.ProjectID))
+			fmt.Println("✓ " + i18n.T("Region set:  %s", cfg.Region))
+			fmt.Println("✓ " + i18n.T("Saved config: %s", store.Path))
+		})
 	},
 }
 
@@ -112,67 +122,68 @@ func init() {
 	initCmd.Flags().StringVar(&initRegion, "region", "", "Default region (e.g. europe-west1)")
 }
 
-func readChoice(min, max int) (int, error) {
-	in := bufio.NewReader(os.Stdin)
-	for {
-		fmt.Printf("Enter choice [%d-%d]: ", min, max)
-		s, _ := in.ReadString('\n')
-		s = strings.TrimSpace(s)
-		n, err := strconv.Atoi(s)
-		if err != nil || n < min || n > max {
-			fmt.Println("Invalid choice.")
-			continue
-		}
-		return n, nil
+// projectPageSize is how many projects are loaded up front; the picker
+// searches server-side for anything beyond them.
+const projectPageSize = 50
+
+// pickProject lets the user choose among their ACTIVE projects, shown with
+// their folder/organization path. Typing filters the loaded projects;
+// Enter on text they don't match (or on any text when the list was cut)
+// runs a server-side search. Returns "" if the account can see no
+// projects at all.
+func pickProject(ctx context.Context, accessToken string) (string, error) {
+	step := ui.StartStep(i18n.T("Loading GCP projects"))
+	projects, more, err := gcpcrm.SearchProjects(ctx, accessToken, gcpcrm.SearchQuery(""), projectPageSize)
+	if err != nil {
+		step.Fail()
+		return "", err
+	}
+	gcpcrm.ResolvePaths(ctx, accessToken, projects)
+	step.Done(fmt.Sprint(len(projects)))
+	if len(projects) == 0 {
+		return "", nil
 	}
-}
-
-func readRegion() string {
-	in := bufio.NewReader(os.Stdin)
 
-	type region struct {
-		ID   string
-		Desc string
+	opts := ui.PickOptions{
+		Partial: more,
+		Search: func(q string) ([]ui.Choice, error) {
+			found, _, err := gcpcrm.SearchProjects(ctx, accessToken, gcpcrm.SearchQuery(q), projectPageSize)
+			if err != nil {
+				return nil, err
+			}
+			gcpcrm.ResolvePaths(ctx, accessToken, found)
+			return projectChoices(found), nil
+		},
+	}
+	if more {
+		opts.Note = i18n.T("First %d projects; type part of an ID or name and press Enter to search the rest.", len(projects))
+		opts.Custom = func(s string) bool { return gcpcrm.ValidateProjectID(s) == nil }
 	}
+	return ui.Pick(i18n.T("Select GCP project:"), projectChoices(projects), opts)
+}
 
-	common := []region{
-		{"europe-west1", "Belgium"},
-		{"europe-west3", "Frankfurt"},
-		{"europe-west4", "Netherlands"},
-		{"europe-west6", "Zurich"},
-		{"us-central1", "Iowa"},
-		{"us-east1", "South Carolina"},
-		{"us-west1", "Oregon"},
-		{"asia-northeast1", "Tokyo"},
-		{"asia-southeast1", "Singapore"},
+func projectChoices(projects []gcpcrm.ProjectEntry) []ui.Choice {
+	choices := make([]ui.Choice, len(projects))
+	for i, p := range projects {
+		c := ui.Choice{Value: p.ProjectID, Hint: p.Path}
+		if strings.TrimSpace(p.DisplayName) != "" && p.DisplayName != p.ProjectID {
+			c.Label = fmt.Sprintf("%s (%s)", p.ProjectID, p.DisplayName)
+		}
+		choices[i] = c
 	}
+	return choices
+}
 
-	fmt.Println()
-	fmt.Println("Select region:")
-	for i, r := range common {
-		fmt.Printf("  [%d] %s (%s)\n", i+1, r.ID, r.Desc)
+// pickRegion picks a default region. With strict=false (live list
+// unavailable) any well-formed region ID typed is accepted.
+func pickRegion(available []regions.Region, strict bool) (string, error) {
+	choices := make([]ui.Choice, len(available))
+	for i, r := range available {
+		choices[i] = ui.Choice{Value: r.ID, Label: r.Label()}
 	}
-	fmt.Printf("  [%d] %s\n", len(common)+1, "Enter custom region")
-
-	for {
-		fmt.Printf("Enter choice [1-%d]: ", len(common)+1)
-		s, _ := in.ReadString('\n')
-		s = strings.TrimSpace(s)
-		n, err := strconv.Atoi(s)
-		if err != nil || n < 1 || n > len(common)+1 {
-			fmt.Println("Invalid choice.")
-			continue
-		}
-		if n <= len(common) {
-			return common[n-1].ID
-		}
-		fmt.Print("Enter region (e.g. europe-west1): ")
-		r, _ := in.ReadString('\n')
-		r = strings.TrimSpace(r)
-		if r == "" {
-			fmt.Println("Region cannot be empty.")
-			continue
-		}
-		return r
+	opts := ui.PickOptions{}
+	if !strict {
+		opts.Custom = regions.LooksLikeID
 	}
-}
\ No newline at end of file
+	return ui.Pick(i18n.T("Select region:"), choices, opts)
+}
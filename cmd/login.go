@@ -12,6 +12,7 @@ import (
 
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/creds"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/oauth"
 )
 
@@ -38,7 +39,7 @@ var loginCmd = &cobra.Command{
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 		defer cancel()
 
-		fmt.Println("Starting local callback server...")
+		fmt.Println(i18n.T("Starting local callback server..."))
 		sess, err := oauth.BeginAuthCodePKCE(oauth.AuthCodeRequest{
 			ClientID: clientID,
 			Scopes:   scopes,
@@ -47,19 +48,19 @@ var loginCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Println("Opening browser for authentication...")
+		fmt.Println(i18n.T("Opening browser for authentication..."))
 		if !openBrowser(sess.AuthURL) {
-			fmt.Println("Could not open browser automatically. Please open this URL:")
+			fmt.Println(i18n.T("Could not open browser automatically. Please open this URL:"))
 			fmt.Printf("  %s\n", sess.AuthURL)
 		}
 
-		fmt.Println("Waiting for authentication to complete in browser...")
+		fmt.Println(i18n.T("Waiting for authentication to complete in browser..."))
 		result, err := sess.Wait(ctx)
 		if err != nil {
 			return err
 		}
 
-		fmt.Println("Exchanging authorization code for tokens...")
+		fmt.Println(i18n.T("Exchanging authorization code for tokens..."))
 		tok, err := oauth.ExchangeAuthCode(
 			ctx,
 			clientID,
@@ -72,7 +73,7 @@ var loginCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Println("Fetching user info...")
+		fmt.Println(i18n.T("Fetching user info..."))
 		me, err := oauth.FetchUserInfo(ctx, tok.AccessToken)
 		if err != nil {
 			return err
@@ -80,9 +81,9 @@ var loginCmd = &cobra.Command{
 
 		fmt.Println()
 		if me.Email != "" {
-			fmt.Printf("✓ Logged in as %s\n", me.Email)
+			fmt.Println("✓ " + i18n.T("Logged in as %s", me.Email))
 		} else {
-			fmt.Println("✓ Logged in")
+			fmt.Println("✓ " + i18n.T("Logged in"))
 		}
 
 		// ---- A3: persist creds locally ----
@@ -94,7 +95,7 @@ var loginCmd = &cobra.Command{
 		expiry := time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
 
 		c := creds.Credentials{
-			Version: 1,
+			Version: creds.CurrentVersion,
 
 			Email:  me.Email,
 			Scopes: scopes,
@@ -109,16 +110,16 @@ var loginCmd = &cobra.Command{
 
 		if c.RefreshToken == "" {
 			// Not fatal, but important for real “local-first” experience
-			fmt.Println("! Warning: refresh_token is empty.")
-			fmt.Println("  This can happen if Google doesn't re-issue refresh tokens on repeated consents.")
-			fmt.Println("  If future commands fail after token expiry, run: advncd login")
+			fmt.Println("! " + i18n.T("Warning: refresh_token is empty."))
+			fmt.Println("  " + i18n.T("This can happen if Google doesn't re-issue refresh tokens on repeated consents."))
+			fmt.Println("  " + i18n.T("If future commands fail after token expiry, run: advncd login"))
 		}
 
 		if err := store.Save(c); err != nil {
 			return err
 		}
 
-		fmt.Printf("✓ Saved credentials: %s\n", store.Path)
+		fmt.Println("✓ " + i18n.T("Saved credentials: %s", store.Path))
 		// ---- end A3 ----
 
 		return nil
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/creds"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
 )
 
 var logoutCmd = &cobra.Command{
@@ -19,7 +20,7 @@ var logoutCmd = &cobra.Command{
 		if err := store.Delete(); err != nil {
 			return err
 		}
-		fmt.Println("✓ Logged out (local credentials removed)")
+		fmt.Println("✓ " + i18n.T("Logged out (local credentials removed)"))
 		return nil
 	},
 }
\ No newline at end of file
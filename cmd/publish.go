@@ -2,39 +2,81 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
-	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/cloudbuild"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/completion"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpartifact"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcprun"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpserviceusage"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gomod"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/preflight"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/projectslug"
-	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpartifact"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
 )
 
 var (
-	publishName string
+	publishName      string
+	publishListFiles bool
+	publishSymlinks  string
+
+	publishBuilder    string
+	publishDockerfile string
+	publishBuildArgs  []string
+	publishTarget     string
 )
 
+// publishRepo is the Artifact Registry repository images are pushed to (MVP).
+const publishRepo = "advncd"
+
 var publishCmd = &cobra.Command{
-	Use:   "publish",
-	Short: "Build and deploy the current Go app to Cloud Run",
+	Use:   "publish [path]",
+	Short: "Build and deploy a Go app to Cloud Run",
+	Long: `Build and deploy the Go main package in path (default: the current
+directory) to Cloud Run. path may be anywhere inside a module: publish
+finds its go.mod and any go.work, and uploads the module together with
+local replace targets and the workspace modules it needs. With Buildpacks
+the module is the root of the upload and the other modules go under its
+.advncd directory, with go.mod and go.work pointing there.
+
+Images are built with Buildpacks, or with docker when the app has a
+Dockerfile (in path or a parent up to the uploaded root). --builder, or
+"builder" in config.json, picks one explicitly. The docker build context
+is the uploaded root (the module, or the monorepo root holding every
+module needed), not the Dockerfile's directory: COPY paths start there.
+
+--build-arg values are stored in plain text in the Cloud Build record
+and the image history; don't pass secrets that way.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		ctx, cancel := commandContext(30 * time.Minute)
 		defer cancel()
 
-		// Auth (valid token)
-		tb, err := auth.GetAccessToken(ctx)
-		if err != nil {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		if err := checkFlag("symlinks", publishSymlinks, cloudbuild.LinkPolicies...); err != nil {
 			return err
 		}
 
-		// Config (project/region)
+		if publishBuilder != "" {
+			if err := checkFlag("builder", publishBuilder, cloudbuild.Builders...); err != nil {
+				return err
+			}
+		}
+
 		cfgStore, err := config.DefaultStore()
 		if err != nil {
 			return err
@@ -43,129 +85,505 @@ var publishCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if cfg == nil || cfg.ProjectID == "" || cfg.Region == "" {
-			fmt.Println("config: not set")
-			fmt.Println("fix: run `advncd init`")
-			return nil
-		}
 
-		// Project root = current directory (MVP)
-		wd, err := os.Getwd()
+		// C0: the module (and workspace) the package belongs to
+		layout, err := gomod.Resolve(dir)
+		noModule := errors.Is(err, gomod.ErrNoModule)
+		if noModule {
+			// Fine for a Dockerfile build: upload the directory as it is.
+			abs, aerr := filepath.Abs(dir)
+			if aerr != nil {
+				return aerr
+			}
+			layout, err = &gomod.Layout{Root: abs, Module: abs, Package: abs}, nil
+		}
 		if err != nil {
 			return err
 		}
-
-		// C0: require go.mod
-		if _, err := os.Stat(filepath.Join(wd, "go.mod")); err != nil {
-			fmt.Println("Not a Go module (go.mod not found in current directory).")
-			fmt.Println("fix: run `advncd publish` from your Go project root (where go.mod is).")
-			return nil
+		builder, err := chooseBuilder(cfg, cfgStore.Path, layout)
+		if err != nil {
+			return err
+		}
+		if noModule && builder.Builder != cloudbuild.BuilderDocker {
+			return apperr.New(preflight.ErrNotGoModule).
+				WithMeta("dir", layout.Package).
+				WithFix("Run advncd publish inside your Go module, or pass its path: advncd publish ./services/api").
+				WithFix("Or add a Dockerfile to build with docker.")
+		}
+		if builder.Builder == cloudbuild.BuilderBuildpacks {
+			// Buildpacks need go.mod at the root: the main module goes
+			// there and the other modules needed under it.
+			if layout, err = layout.AtModule(); err != nil {
+				return err
+			}
+		}
+		if publishListFiles {
+			return listSourceFiles(layout, builder)
 		}
 
-		// Service name = folder slug by default, can override with --name
+		// Service name = package folder slug by default, can override with --name
 		svc := publishName
 		if svc == "" {
-			svc = projectslug.FromPathBase(wd)
+			svc = projectslug.FromPathBase(layout.Package)
 		} else {
 			svc = projectslug.Slugify(svc)
 		}
+		// No usable name: pick one after preflight, when we can list the
+		// region's services; without a terminal there's nobody to ask.
+		if svc == "" && !ui.Interactive() {
+			return apperr.New(preflight.ErrServiceName).
+				WithMeta("dir", layout.Package).
+				WithFix("Run: advncd publish --name <service>")
+		}
+
+		if cfg == nil || cfg.ProjectID == "" || cfg.Region == "" {
+			return apperr.New(config.ErrNotSet).
+				WithMeta("config_path", cfgStore.Path).
+				WithFix("Run: advncd init")
+		}
+
+		repo := publishRepo
+
+		// Preflight: everything publish needs, checked before anything is
+		// uploaded or created, so problems are reported together and early.
+		step := ui.StartStep(i18n.T("Checking project readiness"))
+		pre := preflight.Publish(ctx, preflight.PublishInput{Config: cfg, Repo: repo})
+		if pre.Blocked() {
+			step.Fail()
+		} else {
+			step.Done("")
+		}
+		printChecks(pre.Checks)
+
+		// Disabled APIs are the one blocker we can fix on the spot.
+		if c := pre.Find(preflight.IDAPIs); c != nil && c.Status == preflight.Fail && onlyFailed(&pre.Report, preflight.IDAPIs) {
+			missing := gcpserviceusage.CheckRequired(ctx, pre.Token.AccessToken, pre.ProjectNumber).MissingFor("publish")
+			if len(missing) > 0 {
+				enabled, err := offerEnableAPIs(ctx, pre.Token.AccessToken, cfg.ProjectID, pre.ProjectNumber, missing)
+				if err != nil {
+					return err
+				}
+				if enabled {
+					*c = preflight.CheckAPIs(ctx, pre.Token.AccessToken, pre.ProjectNumber)
+					printChecks([]preflight.Check{*c})
+				}
+			}
+		}
+		if err := pre.Err(); err != nil {
+			return err
+		}
+		fmt.Println()
+		tb := pre.Token
+
 		if svc == "" {
-			fmt.Println("Unable to determine service name.")
-			fmt.Println("fix: run `advncd publish --name <service>`")
-			return nil
+			if svc, err = pickService(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region); err != nil {
+				return err
+			}
 		}
 
-		// Artifact Registry image
-		// repo = advncd (MVP)
-		repo := "advncd"
 		image := fmt.Sprintf("%s-docker.pkg.dev/%s/%s/%s:latest", cfg.Region, cfg.ProjectID, repo, svc)
 
-		fmt.Println("publish:")
-		fmt.Printf("  project: %s\n", cfg.ProjectID)
-		fmt.Printf("  region:  %s\n", cfg.Region)
-		fmt.Printf("  service: %s\n", svc)
-		fmt.Printf("  image:   %s\n", image)
+		fmt.Println(i18n.T("publish:"))
+		fmt.Println("  " + i18n.T("project: %s", cfg.ProjectID))
+		fmt.Println("  " + i18n.T("region:  %s", cfg.Region))
+		fmt.Println("  " + i18n.T("service: %s", svc))
+		fmt.Println("  " + i18n.T("image:   %s", image))
+		if builder.Builder == cloudbuild.BuilderDocker {
+			fmt.Println("  " + i18n.T("builder: docker (%s)", builder.Docker.Dockerfile))
+			for _, k := range builder.FromEnv {
+				fmt.Println("  ! " + i18n.T("--build-arg %s: the local value goes into the Cloud Build record in plain text", k))
+			}
+		}
+		if b := layout.Buildable(); b != "" {
+			fmt.Println("  " + i18n.T("source:  %s", layout.Root))
+			if builder.Builder != cloudbuild.BuilderDocker {
+				fmt.Println("  " + i18n.T("package: %s", b))
+			}
+		}
 		fmt.Println()
 
 		// 1) Build & push container via Cloud Build (Buildpacks)
-		fmt.Println("Ensuring Artifact Registry repo exists...")
-		if err := gcpartifact.EnsureDockerRepo(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region, "advncd"); err != nil {
+		if err := ui.RunStep(i18n.T("Artifact Registry repository"), func(*ui.Step) error {
+			return gcpartifact.EnsureDockerRepo(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region, repo)
+		}); err != nil {
 			return err
 		}
-		fmt.Println("Building (Cloud Build + Buildpacks)...")
-		build, err := cloudbuild.SubmitBuildpacksBuild(ctx, cloudbuild.SubmitRequest{
+
+		sreq := cloudbuild.SubmitRequest{
 			AccessToken: tb.AccessToken,
 			ProjectID:   cfg.ProjectID,
-			SourceDir:   wd,
+			SourceDir:   layout.Root,
 			Image:       image,
+			Builder:     builder.Builder,
+			Docker:      builder.Docker,
+		}
+		if builder.Builder == cloudbuild.BuilderBuildpacks {
+			sreq.Buildable = layout.Buildable()
+		}
+
+		// Archived while uploading; progress is what GCS has stored.
+		step = ui.StartStep(i18n.T("Upload source"))
+		sreq.Archive = archiveOptions(layout, builder, func(msg string) {
+			step.Warnf("%s", i18n.Text(msg))
+		})
+		src, err := cloudbuild.UploadSource(ctx, sreq, func(n int64) {
+			step.Status(formatSize(n))
 		})
 		if err != nil {
+			step.Fail()
 			return err
 		}
+		step.Logf("gs://%s/%s", src.Bucket, src.Object)
+		if src.Reused {
+			step.Done(i18n.T("%s, unchanged since an earlier upload", formatSize(src.Size)))
+		} else {
+			step.Done(formatSize(src.Size))
+		}
 
-		fmt.Printf("✓ Build submitted: %s\n", build.ID)
+		step = ui.StartStep(i18n.T("Submit build"))
+		build, err := cloudbuild.CreateBuild(ctx, sreq, src)
+		if err != nil {
+			step.Fail()
+			return err
+		}
 		if build.LogURL != "" {
-			fmt.Printf("  logs: %s\n", build.LogURL)
+			step.Logf("logs: %s", build.LogURL)
 		}
+		step.Done(build.ID)
+		completion.RememberBuild(cfg.ProjectID, cfg.Region, build.ID, build.Status)
 
-		fmt.Println("Waiting for build to complete...")
+		buildTitle := i18n.T("Build (Cloud Build + Buildpacks)")
+		if builder.Builder == cloudbuild.BuilderDocker {
+			buildTitle = i18n.T("Build (Cloud Build + Docker)")
+		}
+		step = ui.StartStep(buildTitle)
 		final, err := cloudbuild.WaitBuild(ctx, cloudbuild.WaitRequest{
 			AccessToken: tb.AccessToken,
 			ProjectID:   cfg.ProjectID,
 			Region:      cfg.Region,
 			BuildID:     build.ID,
 			PollEvery:   3 * time.Second,
+			OnStatus:    step.Status,
 		})
 		if err != nil {
+			step.Fail()
 			return err
 		}
 
 		if final.Status != "SUCCESS" {
-			fmt.Println("Build did not succeed.")
-			fmt.Printf("status: %s\n", final.Status)
+			step.Fail()
+			ae := apperr.New(cloudbuild.ErrBuildFailed).
+				WithMeta("build_id", build.ID).
+				WithMeta("status", final.Status)
 			if final.LogURL != "" {
-				fmt.Printf("logs: %s\n", final.LogURL)
+				ae = ae.WithMeta("logs", final.LogURL)
 			}
-			fmt.Println("fix: open build logs and check buildpack detection / Go entrypoint.")
-			fmt.Println("fix: ensure your app listens on $PORT (Cloud Run requirement).")
-			fmt.Println("fix: ensure Artifact Registry repo exists: advncd")
-			return nil
+			if builder.Builder == cloudbuild.BuilderDocker {
+				ae = ae.WithFix("Open the build logs and check the Dockerfile steps.")
+			} else {
+				ae = ae.WithFix("Open the build logs and check buildpack detection / Go entrypoint.")
+			}
+			return ae.WithFix("Ensure your app listens on $PORT (Cloud Run requirement).")
 		}
-
-		fmt.Println("✓ Build completed")
+		step.Done("")
 
 		// 2) Deploy to Cloud Run (create or update)
-		fmt.Println("Deploying to Cloud Run...")
-		deployed, err := gcprun.DeployService(ctx, gcprun.DeployRequest{
-			AccessToken: tb.AccessToken,
-			ProjectID:   cfg.ProjectID,
-			Region:      cfg.Region,
-			ServiceName: svc,
-			Image:       image,
-		})
-		if err != nil {
+		var deployed *gcprun.DeployResult
+		if err := ui.RunStep(i18n.T("Deploy to Cloud Run"), func(*ui.Step) error {
+			var err error
+			deployed, err = gcprun.DeployService(ctx, gcprun.DeployRequest{
+				AccessToken: tb.AccessToken,
+				ProjectID:   cfg.ProjectID,
+				Region:      cfg.Region,
+				ServiceName: svc,
+				Image:       image,
+			})
+			return err
+		}); err != nil {
 			return err
 		}
 
-		fmt.Println("✓ Service deployed")
-		fmt.Println("Allowing unauthenticated access...")
-		if err := gcprun.AllowUnauthenticated(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region, svc); err != nil {
+		if err := ui.RunStep(i18n.T("Allow unauthenticated access (IAM)"), func(*ui.Step) error {
+			return gcprun.AllowUnauthenticated(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region, svc)
+		}); err != nil {
 			return err
 		}
-		fmt.Println("✓ Public access enabled")
-		if deployed.URL != "" {
+		completion.RememberService(cfg.ProjectID, cfg.Region, svc, deployed.URL)
+
+		res := ui.PublishResult{
+			ProjectID:    cfg.ProjectID,
+			Region:       cfg.Region,
+			Service:      svc,
+			Image:        image,
+			Builder:      builder.Builder,
+			BuildID:      build.ID,
+			SourceDigest: src.Digest,
+			Buildable:    sreq.Buildable,
+			BuildLogURL:  final.LogURL,
+			Revision:     deployed.Revision,
+			URL:          deployed.URL,
+			Checks:       checkResults(pre.Checks),
+		}
+		return ui.Render(res, func() {
 			fmt.Println()
-			fmt.Printf("URL: %s\n", deployed.URL)
+			if res.Revision != "" {
+				fmt.Println(i18n.T("Revision: %s", res.Revision))
+			}
+			if res.URL != "" {
+				fmt.Println(i18n.T("URL: %s", res.URL))
+			} else {
+				fmt.Println(i18n.T("URL: (not returned)"))
+				fmt.Println(i18n.T("fix: open Cloud Run console to find the service URL."))
+			}
+		})
+	},
+}
+
+func init() {
+	publishCmd.Flags().StringVar(&publishName, "name", "", "Cloud Run service name (defaults to the package folder name)")
+	publishCmd.Flags().StringVar(&publishSymlinks, "symlinks", cloudbuild.LinksReject, "Symlinks pointing outside the source directory: reject, follow (pack the target) or skip")
+	publishCmd.Flags().BoolVar(&publishListFiles, "list-files", false, "Print the files that would be uploaded and the archive size, then exit")
+	publishCmd.Flags().StringVar(&publishBuilder, "builder", "", "Image builder: buildpacks or docker (default: config, else docker if there is a Dockerfile)")
+	publishCmd.Flags().StringVar(&publishDockerfile, "dockerfile", "", "Dockerfile to build with (implies --builder=docker); the build context stays the uploaded root")
+	publishCmd.Flags().StringArrayVar(&publishBuildArgs, "build-arg", nil, "Docker build argument KEY=VALUE, or KEY to pass the local value (repeatable; stored in plain text in the build)")
+	publishCmd.Flags().StringVar(&publishTarget, "target", "", "Docker build stage to build")
+}
+
+// buildChoice is how publish builds the image.
+type buildChoice struct {
+	Builder string
+	Docker  cloudbuild.DockerOptions // Dockerfile relative to the layout root
+	// FromEnv are the --build-arg keys whose value was read from the
+	// local environment.
+	FromEnv []string
+}
+
+// chooseBuilder picks the builder: --builder, then "builder" in config,
+// then docker when --dockerfile is given or a Dockerfile is found from the
+// package directory up to the uploaded root, else buildpacks.
+func chooseBuilder(cfg *config.Config, cfgPath string, layout *gomod.Layout) (buildChoice, error) {
+	c := buildChoice{Builder: publishBuilder}
+	if c.Builder == "" && cfg != nil && cfg.Builder != "" {
+		c.Builder = cfg.Builder
+		if !slices.Contains(cloudbuild.Builders, c.Builder) {
+			return c, apperr.New(config.ErrInvalidValue).
+				WithMeta("config_path", cfgPath).
+				WithMeta("field", "builder").
+				WithMeta("value", c.Builder).
+				WithFix("Use one of: " + strings.Join(cloudbuild.Builders, ", "))
+		}
+	}
+
+	dockerOnly := publishDockerfile != "" || len(publishBuildArgs) > 0 || publishTarget != ""
+	if c.Builder == cloudbuild.BuilderBuildpacks {
+		if dockerOnly {
+			return c, apperr.New(ErrInvalidFlag).
+				WithMeta("flag", "--builder").
+				WithMeta("value", c.Builder).
+				WithFix("--dockerfile, --build-arg and --target need --builder=docker")
+		}
+		return c, nil
+	}
+
+	dockerfile, err := findDockerfile(layout)
+	if err != nil {
+		return c, err
+	}
+	if c.Builder == "" {
+		c.Builder = cloudbuild.BuilderBuildpacks
+		if dockerfile != "" || dockerOnly {
+			c.Builder = cloudbuild.BuilderDocker
+		}
+	}
+	if c.Builder != cloudbuild.BuilderDocker {
+		return c, nil
+	}
+	if dockerfile == "" {
+		return c, apperr.New(preflight.ErrNoDockerfile).
+			WithMeta("dir", layout.Package).
+			WithFix("Pass one: advncd publish --dockerfile <path>").
+			WithFix("Or build with Buildpacks: advncd publish --builder=buildpacks")
+	}
+	c.Docker = cloudbuild.DockerOptions{Dockerfile: dockerfile, Target: publishTarget}
+	for _, a := range publishBuildArgs {
+		if !strings.Contains(a, "=") {
+			// As docker does: a bare KEY takes the local value.
+			v, ok := os.LookupEnv(a)
+			if !ok {
+				return c, apperr.New(ErrInvalidFlag).
+					WithMeta("flag", "--build-arg").
+					WithMeta("value", a).
+					WithFix("Use --build-arg KEY=VALUE, or set KEY in the environment.")
+			}
+			c.FromEnv = append(c.FromEnv, a)
+			a += "=" + v
+		}
+		c.Docker.BuildArgs = append(c.Docker.BuildArgs, a)
+	}
+	return c, nil
+}
+
+// findDockerfile returns --dockerfile, or the Dockerfile closest to the
+// package up to the layout root, relative to the root ("" if none).
+func findDockerfile(layout *gomod.Layout) (string, error) {
+	if publishDockerfile != "" {
+		abs, err := filepath.Abs(publishDockerfile)
+		if err != nil {
+			return "", err
+		}
+		if info, err := os.Stat(abs); err != nil || info.IsDir() {
+			ae := apperr.New(ErrInvalidFlag).
+				WithMeta("flag", "--dockerfile").
+				WithMeta("value", publishDockerfile).
+				WithFix("Pass the path of a Dockerfile.")
+			if err != nil {
+				ae = ae.WithCause(err)
+			}
+			return "", ae
+		}
+		rel, err := filepath.Rel(layout.Root, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", apperr.New(ErrInvalidFlag).
+				WithMeta("flag", "--dockerfile").
+				WithMeta("value", publishDockerfile).
+				WithFix("The Dockerfile must be inside " + layout.Root + ", the directory uploaded.")
+		}
+		return filepath.ToSlash(rel), nil
+	}
+
+	return layout.Nearest("Dockerfile"), nil
+}
+
+// archiveOptions is how publish packs sources: the directories of layout
+// (and the Dockerfile, for docker builds) with its generated go.work and
+// go.mod and the modules moved under the root, --symlinks for links
+// leaving them, and warn for entries left out.
+func archiveOptions(layout *gomod.Layout, builder buildChoice, warn func(msg string)) cloudbuild.ArchiveOptions {
+	o := cloudbuild.ArchiveOptions{Links: publishSymlinks, Warn: warn, Only: layout.Dirs}
+	if o.Only != nil && builder.Builder == cloudbuild.BuilderDocker {
+		o.Only = append(slices.Clip(o.Only), builder.Docker.Dockerfile)
+	}
+	if layout.GoWork != nil {
+		o.Generated = append(o.Generated, cloudbuild.GeneratedFile{Name: "go.work", Data: layout.GoWork})
+		if layout.GoWorkSum != nil {
+			o.Generated = append(o.Generated, cloudbuild.GeneratedFile{Name: "go.work.sum", Data: layout.GoWorkSum})
+		}
+	}
+	if layout.GoMod != nil {
+		o.Generated = append(o.Generated, cloudbuild.GeneratedFile{Name: "go.mod", Data: layout.GoMod})
+	}
+	for _, e := range layout.Extra {
+		o.Extra = append(o.Extra, cloudbuild.ExtraDir{Dir: e.Dir, Name: e.Name})
+	}
+	return o
+}
+
+// listSourceFiles previews the source archive: the files left after
+// .advncdignore/.gcloudignore/.gitignore, and the packed size.
+func listSourceFiles(layout *gomod.Layout, builder buildChoice) error {
+	dir := layout.Root
+	arch, err := cloudbuild.InspectSource(dir, archiveOptions(layout, builder, func(msg string) {
+		fmt.Fprintln(os.Stderr, i18n.T("warning: %s", i18n.Text(msg)))
+	}))
+	if err != nil {
+		return err
+	}
+
+	res := ui.SourceListResult{Dir: dir, IgnoreFiles: arch.IgnoreFiles, Files: []ui.SourceFile{}, ArchiveSize: arch.Size, Digest: "sha256:" + arch.Digest}
+	if builder.Builder == cloudbuild.BuilderDocker {
+		res.Dockerfile = builder.Docker.Dockerfile
+	} else {
+		res.Buildable = layout.Buildable()
+	}
+	for _, f := range arch.Files {
+		res.Files = append(res.Files, ui.SourceFile{Path: f.Path, Size: f.Size, Link: f.Link})
+		res.TotalSize += f.Size
+	}
+	return ui.Render(res, func() {
+		for _, f := range res.Files {
+			if f.Link != "" {
+				fmt.Printf("%10s  %s -> %s\n", i18n.T("link"), f.Path, f.Link)
+				continue
+			}
+			fmt.Printf("%10s  %s\n", formatSize(f.Size), f.Path)
+		}
+		fmt.Println()
+		if len(res.IgnoreFiles) == 0 {
+			fmt.Println(i18n.T("Ignore files: none (defaults only: .git/, /advncd, /bin/)"))
 		} else {
-			fmt.Println()
-			fmt.Println("URL: (not returned)")
-			fmt.Println("fix: open Cloud Run console to find the service URL.")
+			fmt.Println(i18n.T("Ignore files: %s", strings.Join(res.IgnoreFiles, ", ")))
 		}
+		fmt.Println(i18n.T("%d files, %s; archive %s", len(res.Files), formatSize(res.TotalSize), formatSize(res.ArchiveSize)))
+		if res.Dockerfile != "" {
+			fmt.Println(i18n.T("Builds %s with docker from %s", res.Dockerfile, res.Dir))
+		} else if res.Buildable != "" {
+			fmt.Println(i18n.T("Builds %s from %s", res.Buildable, res.Dir))
+		}
+		fmt.Println(res.Digest)
+	})
+}
 
-		return nil
-	},
+// pickService asks which service to deploy: an existing one in the region
+// or a new name typed into the filter.
+func pickService(ctx context.Context, accessToken, projectID, region string) (string, error) {
+	services, err := gcprun.ListServices(ctx, accessToken, projectID, region)
+	if err != nil {
+		return "", err
+	}
+	choices := make([]ui.Choice, len(services))
+	for i, s := range services {
+		choices[i] = ui.Choice{Value: s.Name, Hint: s.URI}
+	}
+	svc, err := ui.Pick(i18n.T("Select Cloud Run service:"), choices, ui.PickOptions{
+		Note:   i18n.T("Type a new name to create a service."),
+		Custom: func(s string) bool { return projectslug.Slugify(s) == s },
+	})
+	if err != nil {
+		return "", err
+	}
+	return svc, nil
 }
 
-func init() {
-	publishCmd.Flags().StringVar(&publishName, "name", "", "Cloud Run service name (defaults to current folder name)")
-}
\ No newline at end of file
+// printChecks prints one line per check: ✓ pass, ! warn, ✗ fail, - skipped.
+func printChecks(checks []preflight.Check) {
+	for _, c := range checks {
+		mark := "✓"
+		switch c.Status {
+		case preflight.Warn:
+			mark = "!"
+		case preflight.Fail:
+			mark = "✗"
+		case preflight.Skip:
+			mark = "-"
+		}
+		line := fmt.Sprintf("  %s %s", mark, i18n.Text(c.Title))
+		if c.Detail != "" {
+			line += ": " + i18n.Text(c.Detail)
+		}
+		fmt.Println(line)
+	}
+}
+
+// onlyFailed reports whether id is the only failed check in r.
+func onlyFailed(r *preflight.Report, id string) bool {
+	for _, c := range r.Checks {
+		if c.Status == preflight.Fail && c.ID != id {
+			return false
+		}
+	}
+	return true
+}
+
+// formatSize prints a byte count for progress lines: 812 B, 3.4 MiB.
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGT"[exp])
+}
@@ -1,14 +1,67 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
 )
 
+var (
+	outputMode string
+	langFlag   string
+	quiet      bool
+	verbose    bool
+)
+
+var ErrInvalidFlag = apperr.E("B-INPUT-004", "Invalid flag value",
+	apperr.WithSummary("A flag was given a value it does not accept."))
+
+// checkFlag fails unless value is one of allowed.
+func checkFlag(name, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return apperr.New(ErrInvalidFlag).
+		WithMeta("flag", "--"+name).
+		WithMeta("value", value).
+		WithFix("Use one of: " + strings.Join(allowed, ", "))
+}
+
+// cmdCtx is the context bounding the running command; see commandContext.
+var (
+	cmdCtx     context.Context
+	cmdTimeout time.Duration
+)
+
+// commandContext returns the context bounding a whole command, to d. If it
+// expires, Execute reports apperr.ErrTimeout with the error it caused.
+func commandContext(d time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	cmdCtx, cmdTimeout = ctx, d
+	return ctx, cancel
+}
+
+// timedOut wraps err in apperr.ErrTimeout if the command's context expired.
+func timedOut(err error) error {
+	if cmdCtx == nil || !errors.Is(cmdCtx.Err(), context.DeadlineExceeded) || errors.Is(err, apperr.ErrTimeout) {
+		return err
+	}
+	return apperr.New(apperr.ErrTimeout).WithCause(err).
+		WithMeta("timeout", cmdTimeout.String()).
+		WithFix("Check what already finished before re-running, e.g. advncd builds list or advncd status.")
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "advncd",
 	Short: "Advncd — local-first developer platform for Google Cloud",
@@ -18,14 +71,11 @@ var rootCmd = &cobra.Command{
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		// Pretty print known errors
-		if ae, ok := err.(*apperr.Error); ok {
-			ui.PrintError(ae)
-			os.Exit(1)
-		}
-		// Fallback
-		ui.PrintPlainError(err)
-		os.Exit(1)
+		err = timedOut(err)
+		// Known errors print with fixes and cause tree, even when wrapped
+		// with %w; the exit code tells scripts the category.
+		ui.PrintError(err)
+		os.Exit(apperr.ExitCode(err))
 	}
 }
 
@@ -37,6 +87,82 @@ func init() {
 	rootCmd.AddCommand(authCmd)
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(publishCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(errorsCmd)
+	rootCmd.AddCommand(i18nCmd)
+	rootCmd.AddCommand(buildsCmd)
+	rootCmd.AddCommand(completionCmd)
+	// Our completion command documents installation and replaces cobra's.
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	
+	rootCmd.AddCommand(gcpCmd)
+	rootCmd.AddCommand(apisCmd)
+
 	authCmd.AddCommand(authPrintAccessTokenCmd)
-}
\ No newline at end of file
+	apisCmd.AddCommand(apisEnableCmd)
+	errorsCmd.AddCommand(errorsListCmd)
+	errorsCmd.AddCommand(errorsExplainCmd)
+	i18nCmd.AddCommand(i18nMissingCmd)
+	buildsCmd.AddCommand(buildsListCmd)
+	buildsCmd.AddCommand(buildsGetCmd)
+
+	gcpCmd.AddCommand(gcpProjectCmd)
+	gcpCmd.AddCommand(gcpRegionCmd)
+	gcpCmd.AddCommand(gcpBillingCmd)
+	gcpProjectCmd.AddCommand(gcpProjectCreateCmd)
+	gcpProjectCmd.AddCommand(gcpProjectListCmd)
+	gcpProjectCmd.AddCommand(gcpProjectSetCmd)
+	gcpRegionCmd.AddCommand(gcpRegionSetCmd)
+	gcpBillingCmd.AddCommand(gcpBillingLinkCmd)
+
+	// Global flags
+	rootCmd.PersistentFlags().BoolVar(&ui.NonInteractive, "non-interactive", false, "Never prompt; fail with a hint listing the missing flags")
+	rootCmd.PersistentFlags().BoolVar(&ui.AssumeYes, "yes", false, "Answer yes to confirmations (implies --non-interactive)")
+	rootCmd.PersistentFlags().StringVarP(&outputMode, "output", "o", ui.OutputTable, "Output format: table, json or yaml (progress goes to stderr for json/yaml)")
+	rootCmd.PersistentFlags().StringVar(&ui.ErrorFormat, "error-format", ui.ErrorFormatText, "Error output on stderr: text or json")
+	rootCmd.PersistentFlags().StringVar(&ui.DumpDir, "dump-dir", "", "Save full API response bodies from errors to this directory (printed ones are truncated)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only print results and errors (no progress)")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Print step details and a debug log (secrets masked)")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "Language of messages: en or ru (default: ADVNCD_LANG, config, LANG)")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		setLang()
+		// Normalize accepts locale forms like ru_RU.UTF-8; anything else
+		// is a typo, not a reason to fall back to English silently.
+		if langFlag != "" && i18n.Normalize(langFlag) == "" {
+			return checkFlag("lang", langFlag, i18n.Supported...)
+		}
+		if ui.AssumeYes {
+			ui.NonInteractive = true
+		}
+		if err := ui.CheckFormat(ui.ErrorFormat, ui.ErrorFormatText, ui.ErrorFormatJSON); err != nil {
+			ui.ErrorFormat = ui.ErrorFormatText
+			return err
+		}
+		if err := ui.SetOutput(outputMode); err != nil {
+			return err
+		}
+		ui.SetVerbosity(quiet, verbose)
+		return nil
+	}
+
+	registerCompletions()
+}
+
+// setLang selects the message language: --lang, then ADVNCD_LANG, the
+// config's lang and LANG (see i18n.Detect). A broken config is reported by
+// the command itself, not here, and an old one is migrated by the
+// commands that load it, never by this lookup.
+func setLang() {
+	if l := i18n.Normalize(langFlag); l != "" {
+		i18n.Set(l)
+		return
+	}
+	var cfgLang string
+	if store, err := config.DefaultStore(); err == nil {
+		if cfg, err := store.Peek(); err == nil && cfg != nil {
+			cfgLang = cfg.Lang
+		}
+	}
+	i18n.Set(i18n.Detect(cfgLang))
+}
@@ -1,23 +1,27 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpbilling"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpcrm"
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpserviceusage"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/regions"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ui"
 )
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show local status (auth + config + API readiness)",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+		ctx, cancel := commandContext(45 * time.Second)
 		defer cancel()
 
 		// Auth + token verification (userinfo)
@@ -36,73 +40,154 @@ var statusCmd = &cobra.Command{
 			return err
 		}
 
-		fmt.Println("auth: ok")
-		fmt.Printf("email: %s\n", me.Email)
-		fmt.Printf("token_expires_in: %s\n", time.Until(tb.Expiry).Truncate(time.Second))
-		fmt.Printf("creds: %s\n", tb.CredsPath)
-
-		fmt.Println()
+		res := ui.StatusResult{
+			Auth: ui.AuthStatus{
+				Email:           me.Email,
+				TokenExpiresAt:  tb.Expiry.UTC(),
+				CredentialsPath: tb.CredsPath,
+			},
+			Config: ui.ConfigStatus{Path: cfgStore.Path},
+		}
 		if cfg == nil || cfg.ProjectID == "" || cfg.Region == "" {
-			fmt.Println("config: not set")
-			fmt.Printf("config_path: %s\n", cfgStore.Path)
-			fmt.Println("fix: run `advncd init`")
-			return nil
+			return ui.Render(res, func() { printStatus(res) })
+		}
+		res.Config.Set = true
+		res.Config.ProjectID = cfg.ProjectID
+		res.Config.Region = cfg.Region
+
+		if available, err := regions.List(ctx, tb.AccessToken, cfg.ProjectID); err == nil {
+			_, ok := regions.Find(available, cfg.Region)
+			res.Config.RegionValid = &ok
+			if !ok {
+				res.Config.RegionSuggestions = regions.Suggest(cfg.Region, available, 3)
+			}
 		}
 
-		fmt.Printf("project: %s\n", cfg.ProjectID)
-		fmt.Printf("region: %s\n", cfg.Region)
-		fmt.Printf("config: %s\n", cfgStore.Path)
+		// Billing: without it Cloud Build / Artifact Registry fail with opaque errors.
+		res.Billing = &ui.BillingStatus{State: ui.StateUnknown}
+		if billing, err := gcpbilling.GetBillingInfo(ctx, tb.AccessToken, cfg.ProjectID); err == nil {
+			if billing.BillingEnabled {
+				res.Billing.State = ui.StateEnabled
+				res.Billing.Account = strings.TrimPrefix(billing.BillingAccountName, "billingAccounts/")
+			} else {
+				res.Billing.State = ui.StateDisabled
+				res.Billing.Fixes = gcpbilling.DisabledError(ctx, tb.AccessToken, cfg.ProjectID).FixWith
+			}
+		}
 
 		// ---- B4: API readiness checks ----
-		fmt.Println()
-		fmt.Println("apis:")
-
 		// Service Usage prefers projectNumber in resource names
 		p, err := gcpcrm.GetProject(ctx, tb.AccessToken, cfg.ProjectID)
 		if err != nil {
-			// Don't fail whole status for readiness; show hint and exit gracefully.
-			fmt.Println("  (unable to resolve project number; skipping API checks)")
-			fmt.Println("  fix: ensure you have access to this project")
-			return nil
+			// Don't fail whole status for readiness; the printer shows a hint.
+			return ui.Render(res, func() { printStatus(res) })
 		}
-		projectNumber := p.ProjectNumber
-
-		required := []string{
-			"run.googleapis.com",            // Cloud Run
-			"cloudbuild.googleapis.com",      // Cloud Build
-			"artifactregistry.googleapis.com",// Artifact Registry
-			"monitoring.googleapis.com",      // Cloud Monitoring
+		res.Config.ProjectNumber = p.ProjectNumber
+
+		readiness := gcpserviceusage.CheckRequired(ctx, tb.AccessToken, p.ProjectNumber)
+		for _, st := range readiness.APIs {
+			state := ui.StateUnknown
+			switch st.State {
+			case gcpserviceusage.StateEnabled:
+				state = ui.StateEnabled
+			case gcpserviceusage.StateDisabled:
+				state = ui.StateDisabled
+			}
+			res.APIs = append(res.APIs, ui.APIStatus{Name: st.Name, State: state})
 		}
+		res.MissingAPIs = readiness.Missing
 
-		missing := []string{}
-
-		for _, svc := range required {
-			state, err := gcpserviceusage.GetServiceState(ctx, tb.AccessToken, projectNumber, svc)
-			if err != nil {
-				// If we can't query one service, show unknown but continue.
-				fmt.Printf("  %s: unknown\n", svc)
-				continue
-			}
-			switch state {
-			case "ENABLED":
-				fmt.Printf("  %s: enabled\n", svc)
-			default:
-				fmt.Printf("  %s: disabled\n", svc)
-				missing = append(missing, svc)
-			}
+		if err := ui.Render(res, func() { printStatus(res) }); err != nil {
+			return err
 		}
 
-		if len(missing) > 0 {
-			fmt.Println()
-			fmt.Println("fix: enable missing APIs in Google Cloud Console → APIs & Services → Library")
-			fmt.Println("missing:")
-			for _, m := range missing {
-				fmt.Printf("  - %s\n", m)
+		if len(res.MissingAPIs) > 0 && !ui.Machine() {
+			// status is read-only unless the user says yes at the prompt;
+			// --yes alone doesn't turn it into a mutating command.
+			if ui.Interactive() {
+				fmt.Println()
+				enableCtx, cancelEnable := commandContext(10 * time.Minute)
+				defer cancelEnable()
+				enabled, err := offerEnableAPIs(enableCtx, tb.AccessToken, cfg.ProjectID, p.ProjectNumber, res.MissingAPIs)
+				if err != nil {
+					return err
+				}
+				if enabled {
+					return nil
+				}
 			}
-			// (Optional hint; still no gcloud dependency)
-			fmt.Println("note: later we'll add `advncd apis enable` to enable these via Google APIs.")
+			fmt.Println(i18n.T("fix: run `advncd apis enable`"))
+			fmt.Println(i18n.T("fix: or enable them in Google Cloud Console → APIs & Services → Library"))
 		}
 
 		return nil
 	},
-}
\ No newline at end of file
+}
+// printStatus is the human rendering of status.
+func printStatus(res ui.StatusResult) {
+	fmt.Println(i18n.T("auth: ok"))
+	fmt.Println(i18n.T("email: %s", res.Auth.Email))
+	fmt.Println(i18n.T("token expires in: %s", time.Until(res.Auth.TokenExpiresAt).Truncate(time.Second)))
+	fmt.Println(i18n.T("credentials: %s", res.Auth.CredentialsPath))
+
+	fmt.Println()
+	if !res.Config.Set {
+		fmt.Println(i18n.T("config: not set"))
+		fmt.Println(i18n.T("config path: %s", res.Config.Path))
+		fmt.Println(i18n.T("fix: run `advncd init`"))
+		return
+	}
+
+	fmt.Println(i18n.T("project: %s", res.Config.ProjectID))
+	fmt.Println(i18n.T("region: %s", res.Config.Region))
+	if res.Config.RegionValid != nil && !*res.Config.RegionValid {
+		fmt.Println("  ! " + i18n.T("region is not available for Cloud Run in this project"))
+		if s := res.Config.RegionSuggestions; len(s) > 0 {
+			fmt.Println("  " + i18n.T("did you mean: %s", strings.Join(s, ", ")))
+		}
+		fmt.Println("  " + i18n.T("fix: run `advncd init --region <region>`"))
+	}
+	fmt.Println(i18n.T("config: %s", res.Config.Path))
+
+	fmt.Println()
+	switch res.Billing.State {
+	case ui.StateEnabled:
+		fmt.Println(i18n.T("billing: enabled (%s)", res.Billing.Account))
+	case ui.StateDisabled:
+		fmt.Println(i18n.T("billing: disabled"))
+		for _, f := range res.Billing.Fixes {
+			fmt.Println("  " + i18n.T("fix: %s", i18n.Text(f)))
+		}
+	default:
+		fmt.Println(i18n.T("billing: unknown (unable to read billing info)"))
+	}
+
+	fmt.Println()
+	fmt.Println(i18n.T("APIs:"))
+	if res.Config.ProjectNumber == "" {
+		fmt.Println("  " + i18n.T("(unable to resolve project number; skipping API checks)"))
+		fmt.Println("  " + i18n.T("fix: ensure you have access to this project"))
+		return
+	}
+	for _, a := range res.APIs {
+		fmt.Printf("  %s: %s\n", a.Name, stateText(a.State))
+	}
+	if len(res.MissingAPIs) > 0 {
+		fmt.Println()
+		fmt.Println(i18n.T("missing:"))
+		for _, m := range res.MissingAPIs {
+			fmt.Printf("  - %s\n", m)
+		}
+	}
+}
+
+// stateText is an API state for people; --output keeps the raw value.
+func stateText(state string) string {
+	switch state {
+	case ui.StateEnabled:
+		return i18n.T("enabled")
+	case ui.StateDisabled:
+		return i18n.T("disabled")
+	}
+	return i18n.T("unknown")
+}
@@ -1,10 +1,16 @@
 module github.com/ADVNCD-Cloud/advncd-cli
 
-go 1.22
+go 1.22.0
 
-require github.com/spf13/cobra v1.8.1
+require (
+	github.com/spf13/cobra v1.8.1
+	golang.org/x/mod v0.22.0
+	golang.org/x/term v0.25.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/sys v0.26.0 // indirect
 )
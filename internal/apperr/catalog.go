@@ -1,64 +1,57 @@
 package apperr
 
-// EPIC A — Auth (examples)
-// Add new entries here. Keep codes stable.
+// EPIC A — Auth (OAuth flows). Other packages define their own entries next
+// to the code that returns them; E registers all of them in one catalog.
+// Keep codes stable.
 var (
-	AuthMissingClientID = E("A-AUTH-001", "Missing Google OAuth client ID")
-	AuthInvalidScopes   = E("A-AUTH-002", "Invalid OAuth scopes")
-
-	AuthHTTPBuild  = E("A-AUTH-010", "Failed to build HTTP request")
-	AuthHTTPDo     = E("A-AUTH-011", "Failed to perform HTTP request")
-	AuthJSONDecode = E("A-AUTH-012", "Failed to decode JSON response")
-
-	AuthDeviceFlowFailed    = E("A-AUTH-100", "Google OAuth Device Flow request failed")
-	AuthDeviceFlowMalformed = E("A-AUTH-101", "Google OAuth Device Flow response is malformed")
-
-	AuthPKCEGen     = E("A-AUTH-200", "Failed to generate PKCE verifier/challenge")
-	AuthStateGen    = E("A-AUTH-201", "Failed to generate OAuth state")
-	AuthListen      = E("A-AUTH-202", "Failed to start localhost callback server")
-	AuthServe       = E("A-AUTH-203", "Callback server failed")
-	AuthAuthURL     = E("A-AUTH-204", "Failed to build OAuth authorization URL")
-	AuthAuthTimeout = E("A-AUTH-205", "Login timed out")
-
-	AuthStateMismatch = E("A-AUTH-210", "OAuth state mismatch")
-	AuthDenied        = E("A-AUTH-211", "Authorization denied")
-	AuthMissingCode   = E("A-AUTH-212", "Missing authorization code in callback")
-
-	AuthTokenExchange = E("A-AUTH-300", "Failed to exchange authorization code for tokens")
-	AuthUserInfo      = E("A-AUTH-301", "Failed to fetch user info")
+	AuthMissingClientID = E("A-AUTH-001", "Missing Google OAuth client ID",
+		WithSummary("The OAuth client ID is not configured, so login can't start."))
+	AuthInvalidScopes = E("A-AUTH-002", "Invalid OAuth scopes",
+		WithSummary("The requested OAuth scopes are empty or malformed."))
+
+	AuthHTTPBuild = E("A-AUTH-010", "Failed to build HTTP request",
+		WithSummary("An HTTP request to Google's OAuth endpoints could not be built."))
+	AuthHTTPDo = E("A-AUTH-011", "Failed to perform HTTP request",
+		WithSummary("Google's OAuth endpoints could not be reached."))
+	AuthJSONDecode = E("A-AUTH-012", "Failed to decode JSON response",
+		WithSummary("Google's OAuth endpoints returned a response advncd could not parse."))
+
+	AuthDeviceFlowFailed = E("A-AUTH-100", "Google OAuth Device Flow request failed",
+		WithSummary("Google rejected the device-code request."))
+	AuthDeviceFlowMalformed = E("A-AUTH-101", "Google OAuth Device Flow response is malformed",
+		WithSummary("The device-code response is missing required fields."))
+
+	AuthPKCEGen = E("A-AUTH-200", "Failed to generate PKCE verifier/challenge",
+		WithSummary("Generating the PKCE secret for browser login failed."))
+	AuthStateGen = E("A-AUTH-201", "Failed to generate OAuth state",
+		WithSummary("Generating the anti-CSRF state for browser login failed."))
+	AuthListen = E("A-AUTH-202", "Failed to start localhost callback server",
+		WithSummary("The local port for the browser login callback could not be opened."))
+	AuthServe = E("A-AUTH-203", "Callback server failed",
+		WithSummary("The local callback server stopped before login completed."))
+	AuthAuthURL = E("A-AUTH-204", "Failed to build OAuth authorization URL",
+		WithSummary("The Google sign-in URL could not be built."))
+	AuthAuthTimeout = E("A-AUTH-205", "Login timed out",
+		WithSummary("The browser sign-in was not completed in time."))
+
+	AuthStateMismatch = E("A-AUTH-210", "OAuth state mismatch",
+		WithSummary("The callback's state does not match the login attempt; it may be stale or forged."))
+	AuthDenied = E("A-AUTH-211", "Authorization denied",
+		WithSummary("Access was denied on the Google consent screen."))
+	AuthMissingCode = E("A-AUTH-212", "Missing authorization code in callback",
+		WithSummary("Google redirected back without an authorization code."))
+
+	AuthTokenExchange = E("A-AUTH-300", "Failed to exchange authorization code for tokens",
+		WithSummary("Google did not exchange the authorization code for tokens."))
+	AuthUserInfo = E("A-AUTH-301", "Failed to fetch user info",
+		WithSummary("The account's e-mail could not be read with the new token."))
 )
 
-// byCode enables restoring an error by code (e.g., logs, dashboard, remote agent).
-var byCode = map[string]Entry{
-	AuthMissingClientID.Code: AuthMissingClientID,
-	AuthInvalidScopes.Code:   AuthInvalidScopes,
-
-	AuthHTTPBuild.Code:  AuthHTTPBuild,
-	AuthHTTPDo.Code:     AuthHTTPDo,
-	AuthJSONDecode.Code: AuthJSONDecode,
-
-	AuthDeviceFlowFailed.Code:    AuthDeviceFlowFailed,
-	AuthDeviceFlowMalformed.Code: AuthDeviceFlowMalformed,
-
-	AuthPKCEGen.Code:     AuthPKCEGen,
-	AuthStateGen.Code:    AuthStateGen,
-	AuthListen.Code:      AuthListen,
-	AuthServe.Code:       AuthServe,
-	AuthAuthURL.Code:     AuthAuthURL,
-	AuthAuthTimeout.Code: AuthAuthTimeout,
-
-	AuthStateMismatch.Code: AuthStateMismatch,
-	AuthDenied.Code:        AuthDenied,
-	AuthMissingCode.Code:   AuthMissingCode,
-	
-	AuthTokenExchange.Code: AuthTokenExchange,
-	AuthUserInfo.Code:      AuthUserInfo,
-}
-
-// FromCode returns a catalog entry for a known code, otherwise a generic entry.
+// FromCode returns a catalog entry for a known code (e.g., from logs,
+// dashboard, remote agent), otherwise a generic, unregistered entry.
 func FromCode(code string) Entry {
-	if e, ok := byCode[code]; ok {
+	if e, ok := Lookup(code); ok {
 		return e
 	}
-	return E(code, "Unknown error")
-}
\ No newline at end of file
+	return Entry{Code: code, Message: "Unknown error", Severity: SeverityError, Title: "Unknown error"}
+}
@@ -1,13 +1,40 @@
 package apperr
 
+// Severity says how bad an error is for the user (ERRORS.md: info|warn|error).
+type Severity string
+
+const (
+	SeverityInfo  Severity = "info"
+	SeverityWarn  Severity = "warn"
+	SeverityError Severity = "error"
+)
+
 // Entry is a catalog item: a stable error code + user-facing message.
 // This is the single source of truth (no separate const + map keys duplication).
 type Entry struct {
-	Code    string
-	Message string
+	Code     string
+	Message  string
+	Severity Severity // defaults to error
+	Title    string   // short "what happened"; defaults to Message
+	Summary  string   // one line on what is wrong and why
+	DocsHint string   // where to read more (doc section, console page)
 }
 
-// E is a small helper to define entries consistently.
-func E(code, message string) Entry {
-	return Entry{Code: code, Message: message}
-}
\ No newline at end of file
+// Option sets optional Entry fields in E.
+type Option func(*Entry)
+
+func WithSeverity(s Severity) Option { return func(e *Entry) { e.Severity = s } }
+func WithTitle(t string) Option      { return func(e *Entry) { e.Title = t } }
+func WithSummary(s string) Option    { return func(e *Entry) { e.Summary = s } }
+func WithDocs(d string) Option       { return func(e *Entry) { e.DocsHint = d } }
+
+// E is a small helper to define entries consistently. Every entry defined
+// with E is registered in the catalog; a duplicate code panics at startup.
+func E(code, message string, opts ...Option) Entry {
+	e := Entry{Code: code, Message: message, Severity: SeverityError, Title: message}
+	for _, o := range opts {
+		o(&e)
+	}
+	register(e)
+	return e
+}
@@ -1,6 +1,11 @@
 package apperr
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/redact"
+)
 
 type Error struct {
 	Code    string
@@ -25,20 +30,76 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+// Unwrap exposes the cause to errors.Is/As and to the UI's cause tree.
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is matches by code, so errors.Is(err, gcpbilling.ErrBillingDisabled) or
+// errors.Is(err, apperr.New(entry)) work anywhere in a chain.
+func (e *Error) Is(target error) bool {
+	switch t := target.(type) {
+	case *Error:
+		return t != nil && t.Code == e.Code
+	case Entry:
+		return t.Code == e.Code
+	}
+	return false
+}
+
 func (e *Error) WithCause(err error) *Error {
 	e.Cause = err
 	return e
 }
 
+// WithMeta stores v with secrets masked: meta often carries raw API bodies,
+// which can echo tokens or signed URLs back.
 func (e *Error) WithMeta(k, v string) *Error {
 	if e.Meta == nil {
 		e.Meta = map[string]string{}
 	}
-	e.Meta[k] = v
+	e.Meta[k] = redact.String(v)
 	return e
 }
 
 func (e *Error) WithFix(f string) *Error {
 	e.FixWith = append(e.FixWith, f)
 	return e
-}
\ No newline at end of file
+}
+
+// Error makes an Entry usable as an errors.Is target.
+func (e Entry) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// As returns the outermost *Error in err's chain.
+func As(err error) (*Error, bool) {
+	var ae *Error
+	if errors.As(err, &ae) {
+		return ae, true
+	}
+	return nil, false
+}
+
+// Chain returns err followed by its causes, outermost first. Errors that
+// wrap several (errors.Join) contribute all of them, depth first.
+func Chain(err error) []error {
+	var out []error
+	var walk func(error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		out = append(out, err)
+		switch u := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				walk(e)
+			}
+		case interface{ Unwrap() error }:
+			walk(u.Unwrap())
+		}
+	}
+	walk(err)
+	return out
+}
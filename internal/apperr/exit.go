@@ -0,0 +1,90 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// Process exit codes by failure category. Scripts may rely on these.
+const (
+	ExitGeneric   = 1
+	ExitAuth      = 3
+	ExitConfig    = 4
+	ExitPreflight = 5
+	ExitBuild     = 6
+	ExitDeploy    = 7
+	ExitNetwork   = 8
+	ExitTimeout   = 9
+)
+
+// ErrTimeout is a command running out of its own time limit. Commands wrap
+// whatever their expired context caused in it, so it isn't mistaken for a
+// network failure.
+var ErrTimeout = E("B-TIMEOUT-001", "Command timed out",
+	WithSummary("The command did not finish within its time limit; work started in Google Cloud may still be running."))
+
+// exitByCode maps code prefixes to exit codes; first match wins, so
+// specific codes go before their group.
+var exitByCode = []struct {
+	prefix string
+	exit   int
+}{
+	{"B-SU-004", ExitPreflight},      // required APIs disabled
+	{"B-BILLING-002", ExitPreflight}, // billing disabled
+	{"B-DOCTOR-", ExitPreflight},
+	{"C-PUBLISH-", ExitPreflight},
+	{"A-", ExitAuth},
+	{"B-CONFIG-", ExitConfig},
+	{"B-REGION-", ExitConfig},
+	{"B-INPUT-", ExitConfig},
+	{"C-BUILD-", ExitBuild},
+	{"C-GCS-", ExitBuild},
+	{"C-AR-", ExitBuild},
+	{"C-RUN-", ExitDeploy},
+}
+
+// ExitCode returns the process exit code for err. A network failure
+// anywhere in the chain wins over the code of the error that wrapped it:
+// "can't reach Google" is what the script needs to know. Only the command
+// timing out wins over that, since its expiry is what failed the requests.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if errors.Is(err, ErrTimeout) {
+		return ExitTimeout
+	}
+	if IsNetwork(err) {
+		return ExitNetwork
+	}
+	ae, ok := As(err)
+	if !ok {
+		return ExitGeneric
+	}
+	for _, m := range exitByCode {
+		if strings.HasPrefix(ae.Code, m.prefix) {
+			return m.exit
+		}
+	}
+	return ExitGeneric
+}
+
+// IsNetwork reports whether err's chain contains a transport-level failure
+// (DNS, connect, TLS, a request's own timeout) rather than an HTTP error
+// response. A bare context deadline is not one: see ErrTimeout.
+func IsNetwork(err error) bool {
+	var ne net.Error
+	if !errors.As(err, &ne) {
+		return false
+	}
+	// Context errors are net.Errors too, and url.Error wraps them when a
+	// context cuts a request: that is the caller giving up, not the network.
+	for e := error(ne); e != nil; e = errors.Unwrap(e) {
+		if e == context.DeadlineExceeded || e == context.Canceled {
+			return false
+		}
+	}
+	return true
+}
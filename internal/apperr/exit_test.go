@@ -0,0 +1,41 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	entry := func(code string) *Error { return &Error{Code: code} }
+	dial := &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	expired := &url.Error{Op: "Get", URL: "https://example.com", Err: context.DeadlineExceeded}
+
+	cases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"plain", errors.New("boom"), ExitGeneric},
+		{"unknown code", entry("Z-1"), ExitGeneric},
+		{"auth", entry("A-AUTH-001"), ExitAuth},
+		{"specific before group", entry("B-SU-004"), ExitPreflight},
+		{"input", entry("B-INPUT-004"), ExitConfig},
+		{"build", entry("C-BUILD-001"), ExitBuild},
+		{"deploy", entry("C-RUN-001"), ExitDeploy},
+		{"transport under a build error", entry("C-BUILD-001").WithCause(dial), ExitNetwork},
+		{"transport wrapped with %w", fmt.Errorf("upload: %w", dial), ExitNetwork},
+		{"bare deadline is not network", entry("C-BUILD-002").WithCause(context.DeadlineExceeded), ExitBuild},
+		{"command timeout", New(ErrTimeout).WithCause(context.DeadlineExceeded), ExitTimeout},
+		{"command timeout over a request it cut", New(ErrTimeout).WithCause(entry("C-GCS-001").WithCause(expired)), ExitTimeout},
+	}
+	for _, c := range cases {
+		if got := ExitCode(c.err); got != c.want {
+			t.Errorf("%s: ExitCode = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package apperr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/debug"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/redact"
+)
+
+// HTTPError is the leaf of a cause chain for a non-2xx Google API response:
+// the status plus the API's own error message when the body has one.
+type HTTPError struct {
+	StatusCode int
+	Status     string // e.g. "PERMISSION_DENIED" from the error body
+	Message    string
+}
+
+func (e *HTTPError) Error() string {
+	s := fmt.Sprintf("HTTP %d", e.StatusCode)
+	if e.Status != "" {
+		s += " " + e.Status
+	} else if t := http.StatusText(e.StatusCode); t != "" {
+		s += " " + t
+	}
+	if e.Message != "" {
+		s += ": " + e.Message
+	}
+	return s
+}
+
+// HTTP builds an HTTPError from a response status and its raw body, which
+// is parsed as the standard Google API error envelope when possible.
+func HTTP(statusCode int, raw []byte) *HTTPError {
+	debug.Logf("HTTP %d response: %s", statusCode, raw)
+	e := &HTTPError{StatusCode: statusCode}
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(raw, &body) == nil {
+		e.Status = body.Error.Status
+		e.Message = redact.String(body.Error.Message)
+	}
+	return e
+}
@@ -0,0 +1,43 @@
+package apperr
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// catalog holds every entry created with E, keyed by code. Entries are
+// package-level vars, so it is complete once all packages are initialized.
+var (
+	catalogMu sync.RWMutex
+	catalog   = map[string]Entry{}
+)
+
+func register(e Entry) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if prev, dup := catalog[e.Code]; dup {
+		panic(fmt.Sprintf("apperr: duplicate error code %s (%q and %q)", e.Code, prev.Message, e.Message))
+	}
+	catalog[e.Code] = e
+}
+
+// Lookup returns the catalog entry for code.
+func Lookup(code string) (Entry, bool) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	e, ok := catalog[code]
+	return e, ok
+}
+
+// All returns every registered entry, sorted by code.
+func All() []Entry {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	out := make([]Entry, 0, len(catalog))
+	for _, e := range catalog {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
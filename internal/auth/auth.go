@@ -11,19 +11,47 @@ import (
 )
 
 var (
-	ErrNotLoggedIn = apperr.E("A-AUTH-402", "Not logged in")
+	ErrNotLoggedIn = apperr.E("A-AUTH-402", "Not logged in",
+		apperr.WithTitle("GCP not connected"),
+		apperr.WithSummary("No credentials found; commands that call Google Cloud need a login."))
+	ErrInsufficientScopes = apperr.E("A-AUTH-403", "Insufficient OAuth scopes",
+		apperr.WithSummary("The saved token lacks the cloud-platform scope every Google Cloud API requires."))
 )
 
+// CloudPlatformScope is required for every Google Cloud API advncd calls.
+const CloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
 // TokenBundle is what most commands need.
 type TokenBundle struct {
 	AccessToken string
 	Expiry      time.Time
 	Email       string
+	Scopes      []string
 	CredsPath   string
 }
 
+// HasScope reports whether the stored grant includes scope.
+func (tb *TokenBundle) HasScope(scope string) bool {
+	for _, s := range tb.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // GetAccessToken loads local creds, refreshes if needed, and returns a valid access token.
 func GetAccessToken(ctx context.Context) (*TokenBundle, error) {
+	return getAccessToken(ctx, false)
+}
+
+// RefreshAccessToken always exchanges the refresh token, even if the cached
+// access token is still valid. Used by doctor to prove the grant still works.
+func RefreshAccessToken(ctx context.Context) (*TokenBundle, error) {
+	return getAccessToken(ctx, true)
+}
+
+func getAccessToken(ctx context.Context, force bool) (*TokenBundle, error) {
 	store, err := creds.DefaultStore()
 	if err != nil {
 		return nil, err
@@ -41,7 +69,7 @@ func GetAccessToken(ctx context.Context) (*TokenBundle, error) {
 	clientSecret := os.Getenv("ADVNCD_GCP_CLIENT_SECRET")
 
 	// Refresh if expiring soon (skew 30s)
-	if time.Until(c.Expiry) < 30*time.Second {
+	if force || time.Until(c.Expiry) < 30*time.Second {
 		tok, err := oauth.RefreshAccessToken(ctx, c.ClientID, clientSecret, c.RefreshToken)
 		if err != nil {
 			return nil, err
@@ -60,6 +88,7 @@ func GetAccessToken(ctx context.Context) (*TokenBundle, error) {
 		AccessToken: c.AccessToken,
 		Expiry:      c.Expiry,
 		Email:       c.Email,
+		Scopes:      c.Scopes,
 		CredsPath:   store.Path,
 	}, nil
 }
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// Small JSON file cache under os.UserCacheDir()/advncd for data that is
+// slow to fetch and changes rarely (regions, project lists, ...).
+// Everything here is best-effort: a broken or missing cache is a miss.
+
+type entry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Data    json.RawMessage `json:"data"`
+}
+
+var unsafeKey = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+func path(key string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "advncd", unsafeKey.ReplaceAllString(key, "_")+".json"), nil
+}
+
+// Load decodes the cached value for key into out if it is younger than maxAge.
+func Load(key string, maxAge time.Duration, out any) bool {
+	p, err := path(key)
+	if err != nil {
+		return false
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return false
+	}
+	var e entry
+	if err := json.Unmarshal(b, &e); err != nil {
+		return false
+	}
+	if time.Since(e.SavedAt) > maxAge {
+		return false
+	}
+	return json.Unmarshal(e.Data, out) == nil
+}
+
+// LoadStale decodes the cached value for key into out whatever its age,
+// for callers that prefer an old answer to none (e.g. offline).
+func LoadStale(key string, out any) bool {
+	return Load(key, 1<<63-1, out)
+}
+
+// Save stores v under key.
+func Save(key string, v any) error {
+	p, err := path(key)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(entry{SavedAt: time.Now(), Data: data})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(p, b, 0o600)
+}
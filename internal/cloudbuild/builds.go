@@ -0,0 +1,132 @@
+package cloudbuild
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+// BuildInfo is a build as listed or fetched, with the fields `advncd builds`
+// shows.
+type BuildInfo struct {
+	ID         string   `json:"id"`
+	Status     string   `json:"status"`
+	CreateTime string   `json:"createTime"`
+	FinishTime string   `json:"finishTime,omitempty"`
+	LogURL     string   `json:"logUrl"`
+	Images     []string `json:"images,omitempty"`
+}
+
+type buildsResp struct {
+	Builds        []BuildInfo `json:"builds"`
+	NextPageToken string      `json:"nextPageToken"`
+}
+
+func buildsURL(projectID, region string) string {
+	if region == "" {
+		region = "global"
+	}
+	return fmt.Sprintf("https://cloudbuild.googleapis.com/v1/projects/%s/locations/%s/builds", projectID, region)
+}
+
+// GetBuild returns one build of projectID in region ("global" if empty).
+func GetBuild(ctx context.Context, accessToken, projectID, region, buildID string) (*BuildInfo, error) {
+	client := &http.Client{Timeout: 20 * time.Second}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, buildsURL(projectID, region)+"/"+url.PathEscape(buildID), nil)
+	if err != nil {
+		return nil, apperr.New(ErrBuildGet).WithCause(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, apperr.New(ErrBuildGet).WithCause(err).
+			WithFix("Check your internet connection and try again.")
+	}
+	raw, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		ae := apperr.New(ErrBuildGet).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
+			WithMeta("http_status", res.Status).
+			WithMeta("build_id", buildID).
+			WithMeta("raw_body", string(raw))
+		if res.StatusCode == http.StatusNotFound {
+			ae = ae.WithFix("List recent builds: advncd builds list")
+		}
+		return nil, ae
+	}
+
+	var out BuildInfo
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, apperr.New(ErrBuildGet).WithCause(err).
+			WithMeta("raw_body", string(raw))
+	}
+	return &out, nil
+}
+
+// ListBuilds returns up to max builds of projectID in region, newest first.
+func ListBuilds(ctx context.Context, accessToken, projectID, region string, max int) ([]BuildInfo, error) {
+	var all []BuildInfo
+	pageToken := ""
+
+	client := &http.Client{Timeout: 20 * time.Second}
+
+	for {
+		u, _ := url.Parse(buildsURL(projectID, region))
+		q := u.Query()
+		if max > 0 && max < 100 {
+			q.Set("pageSize", strconv.Itoa(max))
+		}
+		if pageToken != "" {
+			q.Set("pageToken", pageToken)
+		}
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, apperr.New(ErrBuildList).WithCause(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, apperr.New(ErrBuildList).WithCause(err).
+				WithFix("Check your internet connection and try again.")
+		}
+		raw, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return nil, apperr.New(ErrBuildList).
+				WithCause(apperr.HTTP(res.StatusCode, raw)).
+				WithMeta("http_status", res.Status).
+				WithMeta("raw_body", string(raw)).
+				WithFix("Ensure Cloud Build API is enabled for this project.")
+		}
+
+		var out buildsResp
+		if err := json.Unmarshal(raw, &out); err != nil {
+			return nil, apperr.New(ErrBuildList).WithCause(err).
+				WithMeta("raw_body", string(raw))
+		}
+		all = append(all, out.Builds...)
+
+		if max > 0 && len(all) >= max {
+			return all[:max], nil
+		}
+		if out.NextPageToken == "" {
+			return all, nil
+		}
+		pageToken = out.NextPageToken
+	}
+}
@@ -3,6 +3,17 @@ package cloudbuild
 import "github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
 
 var (
-	ErrBuildSubmit = apperr.E("C-BUILD-001", "Failed to submit Cloud Build")
-	ErrBuildPoll   = apperr.E("C-BUILD-002", "Failed to poll Cloud Build")
-)
\ No newline at end of file
+	ErrBuildSubmit = apperr.E("C-BUILD-001", "Failed to submit Cloud Build",
+		apperr.WithSummary("The source upload or the Cloud Build request failed; see the cause below."))
+	ErrBuildPoll = apperr.E("C-BUILD-002", "Failed to poll Cloud Build",
+		apperr.WithSummary("The build status could not be read while waiting for it."))
+	ErrBuildFailed = apperr.E("C-BUILD-003", "Build did not succeed",
+		apperr.WithTitle("Build failed"),
+		apperr.WithSummary("Cloud Build finished with a failure status; the build logs say why."))
+)
+var (
+	ErrBuildGet = apperr.E("C-BUILD-004", "Failed to get Cloud Build",
+		apperr.WithSummary("The build could not be read; check the ID and region."))
+	ErrBuildList = apperr.E("C-BUILD-005", "Failed to list Cloud Builds",
+		apperr.WithSummary("Cloud Build did not return the builds in this region."))
+)
@@ -3,7 +3,10 @@ package cloudbuild
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,16 +24,18 @@ type createBuildReq struct {
 			Object string `json:"object"`
 		} `json:"storageSource"`
 	} `json:"source"`
-	Steps []struct {
-		Name string   `json:"name"`
-		Args []string `json:"args"`
-	} `json:"steps"`
-	Timeout string `json:"timeout,omitempty"`
+	Steps   []buildStep `json:"steps"`
+	Timeout string      `json:"timeout,omitempty"`
 	Options struct {
 		Logging string `json:"logging,omitempty"`
 	} `json:"options,omitempty"`
 }
 
+type buildStep struct {
+	Name string   `json:"name"`
+	Args []string `json:"args"`
+}
+
 type opResp struct {
 	Name     string `json:"name"`
 	Metadata struct {
@@ -42,52 +47,114 @@ type opResp struct {
 	} `json:"metadata"`
 }
 
-func SubmitBuildpacksBuild(ctx context.Context, req SubmitRequest) (*Build, error) {
-	// 1) archive source
-	tgz, err := TarGzBytes(req.SourceDir)
+// UploadSource archives req.SourceDir and streams the tar.gz to the
+// project's Cloud Build bucket (created on first use) as a resumable
+// upload, so memory stays at one chunk whatever the repository size.
+// onProgress, if set, gets the bytes uploaded so far.
+//
+// The archive is reproducible and named by its SHA-256, so unchanged
+// sources are hashed once and not uploaded again (Source.Reused).
+func UploadSource(ctx context.Context, req SubmitRequest, onProgress func(uploaded int64)) (*Source, error) {
+	arch, err := InspectSource(req.SourceDir, req.Archive)
 	if err != nil {
-		return nil, apperr.New(ErrBuildSubmit).WithCause(err).
-			WithFix("Ensure the current directory is readable.")
+		return nil, err
 	}
+	digest, size := arch.Digest, arch.Size
 
-	// 2) upload to GCS bucket
-	bucket := fmt.Sprintf("%s_cloudbuild", req.ProjectID)
-	object := fmt.Sprintf("advncd/source-%d.tar.gz", time.Now().Unix())
+	bucket := SourceBucket(req.ProjectID)
+	object := "advncd/source-" + digest + ".tar.gz"
 
 	// sanitize: Cloud Build rejects newlines in storageSource fields
 	bucket = strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(bucket, "\n", ""), "\r", ""))
 	object = strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(object, "\n", ""), "\r", ""))
 
-	status, upErr := gcs.UploadObjectMedia(ctx, req.AccessToken, bucket, object, tgz)
+	src := &Source{Bucket: bucket, Object: object, Size: size, Digest: "sha256:" + digest}
+	exists, err := gcs.ObjectExists(ctx, req.AccessToken, bucket, object)
+	if err != nil {
+		return nil, apperr.New(ErrBuildSubmit).WithCause(err).
+			WithMeta("bucket", bucket)
+	}
+	if exists {
+		src.Reused = true
+		return src, nil
+	}
+
+	up, status, upErr := gcs.StartUpload(ctx, req.AccessToken, bucket, object, "application/gzip")
 	if upErr != nil {
 		// Auto-create bucket if missing (404), then retry once
-		if status == 404 {
-			if err := gcs.CreateBucket(
-				ctx,
-				req.AccessToken,
-				req.ProjectID,
-				bucket,
-				detectBuildRegionFromImage(req.Image),
-			); err != nil {
-				return nil, apperr.New(ErrBuildSubmit).WithCause(err).
-					WithMeta("bucket", bucket).
-					WithFix("Unable to auto-create the Cloud Build bucket; create it manually in Cloud Storage.")
-			}
-
-			// retry upload (IMPORTANT: use =, not :=)
-			status, upErr = gcs.UploadObjectMedia(ctx, req.AccessToken, bucket, object, tgz)
-			if upErr != nil {
-				return nil, apperr.New(ErrBuildSubmit).WithCause(upErr).
-					WithMeta("bucket", bucket).
-					WithFix("Bucket was created, but upload still failed. Check IAM permissions for Cloud Storage.")
-			}
-		} else {
+		if status != 404 {
 			return nil, apperr.New(ErrBuildSubmit).WithCause(upErr).
 				WithMeta("bucket", bucket).
 				WithFix("Check Cloud Storage permissions or API status.")
 		}
+		if err := gcs.CreateBucket(
+			ctx,
+			req.AccessToken,
+			req.ProjectID,
+			bucket,
+			detectBuildRegionFromImage(req.Image),
+		); err != nil {
+			return nil, apperr.New(ErrBuildSubmit).WithCause(err).
+				WithMeta("bucket", bucket).
+				WithFix("Unable to auto-create the Cloud Build bucket; create it manually in Cloud Storage.")
+		}
+		if up, _, upErr = gcs.StartUpload(ctx, req.AccessToken, bucket, object, "application/gzip"); upErr != nil {
+			return nil, apperr.New(ErrBuildSubmit).WithCause(upErr).
+				WithMeta("bucket", bucket).
+				WithFix("Bucket was created, but upload still failed. Check IAM permissions for Cloud Storage.")
+		}
 	}
+	up.OnProgress = onProgress
 
+	// The archive is written into a pipe as the upload reads it, and
+	// hashed again: files edited since InspectSource would otherwise be
+	// stored under the wrong name and reused by the next publish.
+	pr, pw := io.Pipe()
+	h := sha256.New()
+	archived := make(chan error, 1)
+	opts := req.Archive
+	opts.Warn = nil // already reported by InspectSource
+	go func() {
+		_, err := writeTarGz(io.MultiWriter(pw, h), req.SourceDir, opts, nil)
+		_ = pw.CloseWithError(err)
+		archived <- err
+	}()
+
+	_, err = up.Send(ctx, pr)
+	// Unblock the archiver if the upload gave up first.
+	_ = pr.CloseWithError(io.ErrClosedPipe)
+	if archErr := <-archived; archErr != nil && !errors.Is(archErr, io.ErrClosedPipe) {
+		return nil, archiveErr(archErr, req.SourceDir)
+	}
+	if err != nil {
+		return nil, apperr.New(ErrBuildSubmit).WithCause(err).
+			WithMeta("bucket", bucket).
+			WithFix("Check Cloud Storage permissions or API status.")
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != digest {
+		_ = gcs.DeleteObject(ctx, req.AccessToken, bucket, object)
+		return nil, apperr.New(ErrBuildSubmit).
+			WithCause(errors.New("source files changed while uploading")).
+			WithMeta("dir", req.SourceDir).
+			WithFix("Wait for editors, generators or builds writing to the directory to finish, then run publish again.")
+	}
+	return src, nil
+}
+
+// archiveErr reports a failure to pack dir. Links or paths leaving the
+// directory carry their own fixes and are returned as they are.
+func archiveErr(err error, dir string) error {
+	if errors.Is(err, ErrSourceEscape) {
+		return err
+	}
+	return apperr.New(ErrBuildSubmit).WithCause(err).
+		WithMeta("dir", dir).
+		WithFix("Ensure the current directory is readable.")
+}
+
+// CreateBuild starts a build of src that publishes req.Image, with
+// Buildpacks or the Dockerfile in it (req.Builder).
+func CreateBuild(ctx context.Context, req SubmitRequest, src *Source) (*Build, error) {
 	// 3) create build in regional Cloud Build endpoint
 	// Note: builds.create is regional: /v1/projects/{project}/locations/{region}/builds
 	endpoint := fmt.Sprintf(
@@ -97,27 +164,17 @@ func SubmitBuildpacksBuild(ctx context.Context, req SubmitRequest) (*Build, erro
 	)
 
 	cb := createBuildReq{}
-	cb.Source.StorageSource.Bucket = bucket
-	cb.Source.StorageSource.Object = object
+	cb.Source.StorageSource.Bucket = src.Bucket
+	cb.Source.StorageSource.Object = src.Object
 	cb.Timeout = "1200s"
 
 	// IMPORTANT: do NOT set an "images" field here.
 	// pack --publish pushes directly to the registry; setting images makes Cloud Build
-	// try to track/push build artifacts and it fails.
+	// try to track/push build artifacts and it fails. Docker builds push
+	// in a step of their own, so both work the same way.
 	cb.Options.Logging = "CLOUD_LOGGING_ONLY"
 
-	cb.Steps = append(cb.Steps, struct {
-		Name string   `json:"name"`
-		Args []string `json:"args"`
-	}{
-		Name: "gcr.io/k8s-skaffold/pack",
-		Args: []string{
-			"build", req.Image,
-			"--builder", "gcr.io/buildpacks/builder:v1",
-			"--path", ".",
-			"--publish",
-		},
-	})
+	cb.Steps = buildSteps(req)
 
 	payload, _ := json.Marshal(cb)
 
@@ -140,6 +197,7 @@ func SubmitBuildpacksBuild(ctx context.Context, req SubmitRequest) (*Build, erro
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		ae := apperr.New(ErrBuildSubmit).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
 			WithMeta("http_status", res.Status).
 			WithMeta("raw_body", string(raw)).
 			WithFix("Ensure Cloud Build API is enabled and you have permission to create builds.").
@@ -163,6 +221,47 @@ func SubmitBuildpacksBuild(ctx context.Context, req SubmitRequest) (*Build, erro
 	return &Build{ID: id, Status: op.Metadata.Build.Status, LogURL: op.Metadata.Build.LogURL}, nil
 }
 
+// buildSteps returns the steps building and pushing req.Image.
+func buildSteps(req SubmitRequest) []buildStep {
+	if req.Builder == BuilderDocker {
+		d := req.Docker
+		dockerfile := d.Dockerfile
+		if dockerfile == "" {
+			dockerfile = "Dockerfile"
+		}
+		args := []string{"build", "-t", req.Image, "-f", dockerfile}
+		for _, a := range d.BuildArgs {
+			args = append(args, "--build-arg", a)
+		}
+		if d.Target != "" {
+			args = append(args, "--target", d.Target)
+		}
+		args = append(args, ".")
+		return []buildStep{
+			{Name: "gcr.io/cloud-builders/docker", Args: args},
+			{Name: "gcr.io/cloud-builders/docker", Args: []string{"push", req.Image}},
+		}
+	}
+
+	args := []string{
+		"build", req.Image,
+		"--builder", "gcr.io/buildpacks/builder:v1",
+		"--path", ".",
+		"--publish",
+	}
+	if req.Buildable != "" {
+		// Monorepos: the source is the whole layout, the app one package.
+		args = append(args, "--env", "GOOGLE_BUILDABLE="+req.Buildable)
+	}
+	return []buildStep{{Name: "gcr.io/k8s-skaffold/pack", Args: args}}
+}
+
+// SourceBucket is the bucket sources are uploaded to (Cloud Build's default
+// "{project}_cloudbuild"); created on first publish if missing.
+func SourceBucket(projectID string) string {
+	return fmt.Sprintf("%s_cloudbuild", projectID)
+}
+
 // MVP: derive build region from image prefix like "europe-west3-docker.pkg.dev/..."
 // If parsing fails, fallback to "global".
 func detectBuildRegionFromImage(image string) string {
@@ -3,84 +3,543 @@ package cloudbuild
 import (
 	"archive/tar"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
-	"bytes"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/ignore"
 )
 
-func writeTarGz(w io.Writer, dir string) error {
+var ErrSourceEscape = apperr.E("C-BUILD-006", "Source entry points outside the source directory",
+	apperr.WithSummary("A symlink (or an archive path) would reach outside the directory being published, which the build cannot see."))
+
+// Policies for symlinks whose target is outside the source directory.
+// Links inside it are always kept as links.
+const (
+	LinksReject = "reject" // fail (default)
+	LinksFollow = "follow" // pack the target's content in place of the link
+	LinksSkip   = "skip"   // leave the link out, with a warning
+)
+
+// LinkPolicies lists the accepted ArchiveOptions.Links values.
+var LinkPolicies = []string{LinksReject, LinksFollow, LinksSkip}
+
+// ArchiveOptions tunes how a source directory is packed.
+type ArchiveOptions struct {
+	// Links is the policy for symlinks leaving the directory (LinksReject
+	// if empty).
+	Links string
+	// Warn, if set, gets entries left out (sockets, devices, skipped
+	// links), already formatted.
+	Warn func(msg string)
+	// Only, if set, limits the archive to these directories (relative to
+	// the source dir, slash-separated) and the directories leading to
+	// them. Links into the rest count as leaving the source dir.
+	Only []string
+	// Generated files are packed at the archive root before the walk and
+	// take the place of files with the same name.
+	Generated []GeneratedFile
+	// Extra directories are packed after the source dir, each under its
+	// Name and with its own ignore files. Directories holding a go.mod
+	// (other modules) are left out of them, and the top-level directory
+	// of each Name takes the place of one with that name in the source.
+	Extra []ExtraDir
+}
+
+// ExtraDir is a directory from outside the source dir.
+type ExtraDir struct {
+	Dir  string
+	Name string // slash-separated
+}
+
+// GeneratedFile is archive content that is not on disk.
+type GeneratedFile struct {
+	Name string
+	Data []byte
+}
+
+// SourceFile is one non-directory entry of the source archive.
+type SourceFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	Link string `json:"link,omitempty"` // symlink target, for links
+}
+
+// Archive describes the tar.gz packed from a directory.
+type Archive struct {
+	Digest      string // hex SHA-256 of the tar.gz
+	Size        int64  // bytes of the tar.gz
+	Files       []SourceFile
+	IgnoreFiles []string // ignore files that shaped Files, relative to the dir
+}
+
+// InspectSource packs dir without keeping the result and returns what
+// UploadSource would send: the digest, size and entries.
+func InspectSource(dir string, o ArchiveOptions) (*Archive, error) {
+	h := sha256.New()
+	var n countWriter
+	a := &Archive{}
+	m, err := writeTarGz(io.MultiWriter(h, &n), dir, o, func(f SourceFile) {
+		a.Files = append(a.Files, f)
+	})
+	if err != nil {
+		return nil, archiveErr(err, dir)
+	}
+	a.Digest = hex.EncodeToString(h.Sum(nil))
+	a.Size = int64(n)
+	a.IgnoreFiles = m.Sources
+	return a, nil
+}
+
+type countWriter int64
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	*c += countWriter(len(p))
+	return len(p), nil
+}
+
+// writeTarGz packs dir reproducibly: the same files give the same bytes,
+// whoever runs it and whenever. Entries come in lexical order (the walk
+// is sorted), headers keep only name, type, size and an executable bit,
+// and the gzip header has no name or time. onFile, if set, sees every
+// non-directory entry.
+func writeTarGz(w io.Writer, dir string, o ArchiveOptions, onFile func(SourceFile)) (m *ignore.Matcher, err error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	// Links are judged against the real path, so a source dir reached
+	// through a symlink (e.g. /tmp on macOS) still contains its files.
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, err
+	}
+	if m, err = ignore.New(root); err != nil {
+		return nil, err
+	}
+
 	gw := gzip.NewWriter(w)
-	defer gw.Close()
+	gw.Header = gzip.Header{OS: 255} // unknown; Go's default, pinned
+	a := &archiver{
+		tw:        tar.NewWriter(gw),
+		m:         m,
+		realRoot:  realRoot,
+		opts:      o,
+		onFile:    onFile,
+		extraDirs: map[string]bool{},
+		following: map[string]bool{},
+	}
+	defer func() {
+		if cerr := a.tw.Close(); err == nil {
+			err = cerr
+		}
+		if cerr := gw.Close(); err == nil {
+			err = cerr
+		}
+	}()
 
-	tw := tar.NewWriter(gw)
-	defer tw.Close()
+	for _, g := range o.Generated {
+		if err := a.writeGenerated(g); err != nil {
+			return m, err
+		}
+	}
 
-	// Excludes for MVP
-	exclude := func(rel string) bool {
-		rel = filepath.ToSlash(rel)
-		if rel == ".git" || strings.HasPrefix(rel, ".git/") {
-			return true
+	// .advncdignore / .gcloudignore / .gitignore, see internal/ignore.
+	err = m.Walk(func(p, rel string, info fs.FileInfo) error {
+		switch {
+		case a.generated(rel):
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		case !a.included(rel):
+			if info.IsDir() && !a.leadsTo(rel) {
+				return filepath.SkipDir
+			}
+			if !info.IsDir() {
+				return nil
+			}
 		}
-		if rel == "advncd" || strings.HasPrefix(rel, "advncd/") {
-			return true
+		return a.add(p, rel, info)
+	})
+	if err != nil {
+		return m, err
+	}
+	for _, e := range o.Extra {
+		sources, err := a.addExtra(e)
+		if err != nil {
+			return m, err
 		}
-		if rel == "bin" || strings.HasPrefix(rel, "bin/") {
-			return true
+		m.Sources = append(m.Sources, sources...)
+	}
+	return m, nil
+}
+
+// addExtra packs the directory e under e.Name and returns the ignore
+// files that applied, named as in the archive.
+func (a *archiver) addExtra(e ExtraDir) ([]string, error) {
+	if err := safeName(e.Name); err != nil {
+		return nil, err
+	}
+	root, err := filepath.Abs(e.Dir)
+	if err != nil {
+		return nil, err
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, err
+	}
+	m, err := ignore.New(root)
+	if err != nil {
+		return nil, err
+	}
+	sub := &archiver{
+		tw:        a.tw,
+		m:         m,
+		realRoot:  realRoot,
+		prefix:    e.Name,
+		opts:      ArchiveOptions{Links: a.opts.Links, Warn: a.opts.Warn},
+		onFile:    a.onFile,
+		following: a.following,
+	}
+	// The directories leading to it (once), then its content.
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	dirs := strings.Split(e.Name, "/")
+	for i := range dirs {
+		dir := strings.Join(dirs[:i+1], "/")
+		if a.extraDirs[dir] {
+			continue
+		}
+		a.extraDirs[dir] = true
+		if err := a.writeHeader(dir, info, ""); err != nil {
+			return nil, err
+		}
+	}
+	err = m.Walk(func(p, rel string, info fs.FileInfo) error {
+		if info.IsDir() {
+			if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		return sub.add(p, rel, info)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]string, len(m.Sources))
+	for i, s := range m.Sources {
+		sources[i] = e.Name + "/" + s
+	}
+	return sources, nil
+}
+
+type archiver struct {
+	tw       *tar.Writer
+	m        *ignore.Matcher
+	realRoot string
+	prefix   string // archive name of realRoot, "" for the root
+	opts     ArchiveOptions
+	onFile   func(SourceFile)
+	// extraDirs are the directories written for ExtraDir names.
+	extraDirs map[string]bool
+	// following holds the real paths of directories being packed through
+	// a followed link, to stop on cycles.
+	following map[string]bool
+}
+
+// add writes one walked entry; p is its path on disk and rel its name in
+// the archive.
+func (a *archiver) add(p, rel string, info fs.FileInfo) error {
+	if err := safeName(rel); err != nil {
+		return err
+	}
+	mode := info.Mode()
+	switch {
+	case mode.IsDir():
+		return a.writeHeader(a.name(rel), info, "")
+	case mode.IsRegular():
+		return a.writeFile(p, rel, info)
+	case mode&fs.ModeSymlink != 0:
+		return a.addLink(p, rel)
+	default:
+		a.warn(fmt.Sprintf("skipped %s: not a regular file, directory or symlink (%s)", a.name(rel), mode.Type()))
+		return nil
+	}
+}
+
+// addLink keeps a link whose target is inside the source dir (made
+// relative if it was absolute) and applies the policy to the others.
+func (a *archiver) addLink(p, rel string) error {
+	target, err := os.Readlink(p)
+	if err != nil {
+		return err
+	}
+	abs := target
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(filepath.Dir(p), target)
+	}
+	// next is what the link names (its directory resolved); resolved
+	// follows any further links. A dangling link is judged by next.
+	next := abs
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(abs)); err == nil {
+		next = filepath.Join(dir, filepath.Base(abs))
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		resolved = next
+	}
+
+	if inRel, ok := within(a.realRoot, resolved); ok && a.included(inRel) {
+		// Keep the link as written when it is relative and, read from its
+		// place in the archive, names the same entry. Otherwise (absolute,
+		// or via a path leaving the tree) point straight at the target.
+		link := filepath.ToSlash(target)
+		nextRel, nextIn := within(a.realRoot, next)
+		if filepath.IsAbs(target) || !nextIn || path.Join(path.Dir(rel), link) != nextRel {
+			rewritten, err := filepath.Rel(filepath.FromSlash(path.Dir(rel)), filepath.FromSlash(inRel))
+			if err != nil {
+				return err
+			}
+			link = filepath.ToSlash(rewritten)
+		}
+		hdr := &tar.Header{Typeflag: tar.TypeSymlink, Name: a.name(rel), Linkname: link, Mode: 0o777, ModTime: time.Unix(0, 0)}
+		if err := a.tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		a.file(SourceFile{Path: hdr.Name, Link: link})
+		return nil
+	}
+
+	switch a.opts.Links {
+	case LinksSkip:
+		a.warn(fmt.Sprintf("skipped %s: symlink to %s is outside the source directory", a.name(rel), target))
+		return nil
+	case LinksFollow:
+		return a.follow(resolved, rel, target)
+	default:
+		return apperr.New(ErrSourceEscape).
+			WithMeta("path", a.name(rel)).
+			WithMeta("target", target).
+			WithFix("Pack the link's content instead: advncd publish --symlinks=follow").
+			WithFix("Or leave such links out: advncd publish --symlinks=skip").
+			WithFix("Or ignore the path in .advncdignore")
+	}
+}
+
+// follow packs the real file or directory at resolved under rel.
+func (a *archiver) follow(resolved, rel, target string) error {
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return apperr.New(ErrSourceEscape).WithCause(err).
+			WithMeta("path", a.name(rel)).
+			WithMeta("target", target).
+			WithFix("Fix or remove the dangling link, or use --symlinks=skip")
+	}
+	if !info.IsDir() {
+		if !info.Mode().IsRegular() {
+			a.warn(fmt.Sprintf("skipped %s: link target %s is not a regular file", a.name(rel), target))
+			return nil
 		}
-		return false
+		return a.writeFile(resolved, rel, info)
 	}
 
-	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+	if a.following[resolved] {
+		a.warn(fmt.Sprintf("skipped %s: symlink loop through %s", a.name(rel), target))
+		return nil
+	}
+	a.following[resolved] = true
+	defer delete(a.following, resolved)
+
+	// The target's own ignore files are not read; the source dir's rules
+	// apply to the paths as they appear in the archive.
+	return filepath.Walk(resolved, func(p string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		rel, err := filepath.Rel(dir, path)
+		sub, err := filepath.Rel(resolved, p)
 		if err != nil {
 			return err
 		}
-		if rel == "." {
-			return nil
+		name := rel
+		if sub != "." {
+			name = rel + "/" + filepath.ToSlash(sub)
 		}
-		if exclude(rel) {
+		if a.m.Match(name, info.IsDir()) {
 			if info.IsDir() {
 				return filepath.SkipDir
 			}
 			return nil
 		}
+		// Links in there resolve against the real tree, so one pointing
+		// back into the source dir is kept as a link.
+		return a.add(p, name, info)
+	})
+}
 
-		hdr, err := tar.FileInfoHeader(info, "")
-		if err != nil {
-			return err
+func (a *archiver) writeFile(p, rel string, info fs.FileInfo) error {
+	name := a.name(rel)
+	if err := a.writeHeader(name, info, ""); err != nil {
+		return err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	// Exactly the size in the header: a file that grew or shrank since
+	// the walk fails here instead of corrupting the archive.
+	if _, err := io.CopyN(a.tw, f, info.Size()); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	a.file(SourceFile{Path: name, Size: info.Size()})
+	return nil
+}
+
+func (a *archiver) writeGenerated(g GeneratedFile) error {
+	hdr := &tar.Header{Typeflag: tar.TypeReg, Name: g.Name, Size: int64(len(g.Data)), Mode: 0o644, ModTime: time.Unix(0, 0)}
+	if err := a.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if _, err := a.tw.Write(g.Data); err != nil {
+		return err
+	}
+	a.file(SourceFile{Path: g.Name, Size: hdr.Size})
+	return nil
+}
+
+// included reports whether rel is inside one of opts.Only (or there is
+// no such limit).
+func (a *archiver) included(rel string) bool {
+	if len(a.opts.Only) == 0 {
+		return true
+	}
+	for _, dir := range a.opts.Only {
+		if dir == "." || rel == dir || strings.HasPrefix(rel, dir+"/") {
+			return true
 		}
-		hdr.Name = filepath.ToSlash(rel)
-		hdr.ModTime = time.Unix(0, 0) // stable-ish
+	}
+	return false
+}
 
-		if err := tw.WriteHeader(hdr); err != nil {
-			return err
+// leadsTo reports whether directory rel is on the way to one of
+// opts.Only; it is packed, but only for what lies on that way.
+func (a *archiver) leadsTo(rel string) bool {
+	for _, dir := range a.opts.Only {
+		if strings.HasPrefix(dir, rel+"/") {
+			return true
 		}
+	}
+	return false
+}
 
-		if info.Mode().IsRegular() {
-			f, err := os.Open(path)
-			if err != nil {
-				return err
-			}
-			defer f.Close()
-			if _, err := io.Copy(tw, f); err != nil {
-				return err
-			}
+// generated reports whether rel gives way to generated content: a
+// generated file, or the top directory of an extra one.
+func (a *archiver) generated(rel string) bool {
+	for _, g := range a.opts.Generated {
+		if g.Name == rel {
+			return true
 		}
-		return nil
-	})
+	}
+	for _, e := range a.opts.Extra {
+		if top, _, _ := strings.Cut(e.Name, "/"); top == rel {
+			return true
+		}
+	}
+	return false
+}
+
+// name is the archive name of rel, a path relative to realRoot.
+func (a *archiver) name(rel string) string {
+	if a.prefix == "" {
+		return rel
+	}
+	return a.prefix + "/" + rel
 }
 
-// TarGzBytes archives dir to tar.gz bytes (MVP: in-memory).
-func TarGzBytes(dir string) ([]byte, error) {
-	var buf bytes.Buffer
-	if err := writeTarGz(&buf, dir); err != nil {
+func (a *archiver) writeHeader(rel string, info fs.FileInfo, link string) error {
+	hdr, err := tarHeader(rel, info, link)
+	if err != nil {
+		return err
+	}
+	return a.tw.WriteHeader(hdr)
+}
+
+func (a *archiver) file(f SourceFile) {
+	if a.onFile != nil {
+		a.onFile(f)
+	}
+}
+
+func (a *archiver) warn(msg string) {
+	if a.opts.Warn != nil {
+		a.opts.Warn(msg)
+	}
+}
+
+// tarHeader drops everything machine- or checkout-specific from info:
+// times, owner, group and permission bits other than executable.
+func tarHeader(rel string, info fs.FileInfo, link string) (*tar.Header, error) {
+	hdr, err := tar.FileInfoHeader(info, link)
+	if err != nil {
 		return nil, err
 	}
-	return buf.Bytes(), nil
-}
\ No newline at end of file
+	hdr.Name = rel
+	hdr.ModTime = time.Unix(0, 0)
+	hdr.AccessTime, hdr.ChangeTime = time.Time{}, time.Time{}
+	hdr.Uid, hdr.Gid = 0, 0
+	hdr.Uname, hdr.Gname = "", ""
+	hdr.PAXRecords = nil
+	switch {
+	case info.IsDir():
+		hdr.Name += "/"
+		hdr.Mode = 0o755
+	case info.Mode()&0o111 != 0:
+		hdr.Mode = 0o755
+	default:
+		hdr.Mode = 0o644
+	}
+	return hdr, nil
+}
+
+// safeName rejects archive names that could land outside the build
+// context when extracted: absolute paths, "..", and drive or UNC forms.
+func safeName(rel string) error {
+	bad := rel == "" || path.IsAbs(rel) || strings.ContainsRune(rel, 0) ||
+		filepath.VolumeName(rel) != "" || path.Clean(rel) != rel
+	for _, part := range strings.Split(rel, "/") {
+		if part == ".." {
+			bad = true
+		}
+	}
+	if bad {
+		return apperr.New(ErrSourceEscape).
+			WithMeta("path", rel).
+			WithFix("Rename the file or ignore it in .advncdignore")
+	}
+	return nil
+}
+
+// within returns p relative to root (slash-separated) if p is root or
+// inside it.
+func within(root, p string) (string, bool) {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return "", false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") || filepath.IsAbs(rel) {
+		return "", false
+	}
+	return rel, true
+}
@@ -0,0 +1,428 @@
+package cloudbuild
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gomod"
+)
+
+// packed is an archive read back: headers and contents by name, and the
+// warnings given while packing.
+type packed struct {
+	digest   string
+	headers  map[string]*tar.Header
+	content  map[string]string
+	warnings []string
+}
+
+func pack(t *testing.T, dir string, o ArchiveOptions) (*packed, error) {
+	t.Helper()
+	p := &packed{headers: map[string]*tar.Header{}, content: map[string]string{}}
+	o.Warn = func(msg string) { p.warnings = append(p.warnings, msg) }
+	a, err := InspectSource(dir, o)
+	if err != nil {
+		return nil, err
+	}
+	p.digest = a.Digest
+
+	var buf bytes.Buffer
+	o.Warn = nil
+	if _, err := writeTarGz(&buf, dir, o, nil); err != nil {
+		t.Fatal(err)
+	}
+	gr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.headers[hdr.Name] = hdr
+		p.content[hdr.Name] = string(b)
+	}
+	return p, nil
+}
+
+func mustPack(t *testing.T, dir string, o ArchiveOptions) *packed {
+	t.Helper()
+	p, err := pack(t, dir, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func write(t *testing.T, path, data string, mode os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), mode); err != nil {
+		t.Fatal(err)
+	}
+	// WriteFile keeps the mode of an existing file and umask applies.
+	if err := os.Chmod(path, mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func symlink(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(link), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+}
+
+func TestArchiveDeterministic(t *testing.T) {
+	tree := func(mode os.FileMode, mtime time.Time) string {
+		dir := t.TempDir()
+		write(t, filepath.Join(dir, "go.mod"), "module x\n", mode)
+		write(t, filepath.Join(dir, "cmd", "main.go"), "package main\n", mode)
+		write(t, filepath.Join(dir, "run.sh"), "#!/bin/sh\n", 0o750)
+		for _, p := range []string{"go.mod", "cmd/main.go", "run.sh", "cmd"} {
+			if err := os.Chtimes(filepath.Join(dir, p), mtime, mtime); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return dir
+	}
+	a := mustPack(t, tree(0o644, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), ArchiveOptions{})
+	b := mustPack(t, tree(0o600, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)), ArchiveOptions{})
+	if a.digest != b.digest {
+		t.Errorf("same files, different digests: %s and %s", a.digest, b.digest)
+	}
+	again := mustPack(t, tree(0o644, time.Now()), ArchiveOptions{})
+	if again.digest != a.digest {
+		t.Errorf("digest changed between runs: %s and %s", a.digest, again.digest)
+	}
+
+	wantMode := map[string]int64{"go.mod": 0o644, "cmd/": 0o755, "cmd/main.go": 0o644, "run.sh": 0o755}
+	for name, mode := range wantMode {
+		h, ok := a.headers[name]
+		if !ok {
+			t.Errorf("%s: not in archive", name)
+			continue
+		}
+		if h.Mode != mode {
+			t.Errorf("%s: mode %o, want %o", name, h.Mode, mode)
+		}
+		if !h.ModTime.Equal(time.Unix(0, 0)) || h.Uid != 0 || h.Gid != 0 || h.Uname != "" || h.Gname != "" {
+			t.Errorf("%s: header keeps machine details: %+v", name, h)
+		}
+	}
+}
+
+func TestArchiveLinksInside(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "data", "a.txt"), "a", 0o644)
+	symlink(t, "data/a.txt", filepath.Join(dir, "rel"))
+	symlink(t, filepath.Join(dir, "data", "a.txt"), filepath.Join(dir, "abs"))
+	// Relative, but through the parent of the source dir.
+	symlink(t, filepath.Join("..", "..", filepath.Base(dir), "data", "a.txt"), filepath.Join(dir, "sub", "round"))
+	symlink(t, "../data", filepath.Join(dir, "sub", "dirlink"))
+
+	p := mustPack(t, dir, ArchiveOptions{})
+	want := map[string]string{
+		"rel":         "data/a.txt",
+		"abs":         "data/a.txt",
+		"sub/round":   "../data/a.txt",
+		"sub/dirlink": "../data",
+	}
+	for name, link := range want {
+		h, ok := p.headers[name]
+		switch {
+		case !ok:
+			t.Errorf("%s: not in archive", name)
+		case h.Typeflag != tar.TypeSymlink:
+			t.Errorf("%s: type %c, want a symlink", name, h.Typeflag)
+		case h.Linkname != link:
+			t.Errorf("%s: links to %q, want %q", name, h.Linkname, link)
+		}
+	}
+	if len(p.warnings) != 0 {
+		t.Errorf("warnings: %v", p.warnings)
+	}
+}
+
+func TestArchiveLinksOutside(t *testing.T) {
+	outside := t.TempDir()
+	write(t, filepath.Join(outside, "shared.txt"), "shared", 0o644)
+	write(t, filepath.Join(outside, "lib", "lib.go"), "package lib\n", 0o644)
+
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "main.go"), "package main\n", 0o644)
+	symlink(t, filepath.Join(outside, "shared.txt"), filepath.Join(dir, "shared.txt"))
+	symlink(t, filepath.Join(outside, "lib"), filepath.Join(dir, "lib"))
+
+	t.Run("reject", func(t *testing.T) {
+		for _, links := range []string{"", LinksReject} {
+			if _, err := pack(t, dir, ArchiveOptions{Links: links}); !errors.Is(err, ErrSourceEscape) {
+				t.Errorf("Links=%q: err = %v, want %v", links, err, ErrSourceEscape)
+			}
+		}
+	})
+	t.Run("skip", func(t *testing.T) {
+		p := mustPack(t, dir, ArchiveOptions{Links: LinksSkip})
+		for _, name := range []string{"shared.txt", "lib", "lib/lib.go"} {
+			if _, ok := p.headers[name]; ok {
+				t.Errorf("%s: packed, want skipped", name)
+			}
+		}
+		if _, ok := p.headers["main.go"]; !ok {
+			t.Error("main.go: not in archive")
+		}
+		if len(p.warnings) != 2 {
+			t.Errorf("warnings = %v, want one per link", p.warnings)
+		}
+	})
+	t.Run("follow", func(t *testing.T) {
+		p := mustPack(t, dir, ArchiveOptions{Links: LinksFollow})
+		if h := p.headers["shared.txt"]; h == nil || h.Typeflag != tar.TypeReg || p.content["shared.txt"] != "shared" {
+			t.Errorf("shared.txt: want the target's content, got %+v", h)
+		}
+		if h := p.headers["lib/"]; h == nil || h.Typeflag != tar.TypeDir {
+			t.Errorf("lib/: want a directory, got %+v", h)
+		}
+		if p.content["lib/lib.go"] != "package lib\n" {
+			t.Errorf("lib/lib.go = %q", p.content["lib/lib.go"])
+		}
+	})
+}
+
+func TestArchiveFollowCycle(t *testing.T) {
+	outside := t.TempDir()
+	write(t, filepath.Join(outside, "a.go"), "package a\n", 0o644)
+	symlink(t, outside, filepath.Join(outside, "loop"))
+
+	dir := t.TempDir()
+	symlink(t, outside, filepath.Join(dir, "ext"))
+
+	// Without cycle detection this never returns.
+	p := mustPack(t, dir, ArchiveOptions{Links: LinksFollow})
+	if p.content["ext/a.go"] != "package a\n" {
+		t.Errorf("ext/a.go = %q", p.content["ext/a.go"])
+	}
+	if _, ok := p.headers["ext/loop/a.go"]; ok {
+		t.Error("loop was followed")
+	}
+	if len(p.warnings) != 1 || !strings.Contains(p.warnings[0], "symlink loop") {
+		t.Errorf("warnings = %v, want one about the loop", p.warnings)
+	}
+}
+
+func TestArchiveSkipsSpecialFiles(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "main.go"), "package main\n", 0o644)
+	l, err := net.Listen("unix", filepath.Join(dir, "agent.sock"))
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+	defer l.Close()
+
+	p := mustPack(t, dir, ArchiveOptions{})
+	if _, ok := p.headers["agent.sock"]; ok {
+		t.Error("socket packed")
+	}
+	if _, ok := p.headers["main.go"]; !ok {
+		t.Error("main.go: not in archive")
+	}
+	if len(p.warnings) != 1 || !strings.Contains(p.warnings[0], "agent.sock") {
+		t.Errorf("warnings = %v, want one about agent.sock", p.warnings)
+	}
+}
+
+func TestSafeName(t *testing.T) {
+	for _, name := range []string{"a.go", "dir/a.go", ".hidden"} {
+		if err := safeName(name); err != nil {
+			t.Errorf("safeName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "/etc/passwd", "../x", "a/../../x", "a//b", "a\x00b"} {
+		if err := safeName(name); !errors.Is(err, ErrSourceEscape) {
+			t.Errorf("safeName(%q) = %v, want %v", name, err, ErrSourceEscape)
+		}
+	}
+}
+
+func TestArchiveExtraDirs(t *testing.T) {
+	lib := t.TempDir()
+	write(t, filepath.Join(lib, "lib.go"), "package lib\n", 0o644)
+	write(t, filepath.Join(lib, ".gitignore"), "*.tmp\n", 0o644)
+	write(t, filepath.Join(lib, "x.tmp"), "", 0o644)
+	write(t, filepath.Join(lib, "nested", "go.mod"), "module example.com/nested\n", 0o644)
+	symlink(t, "lib.go", filepath.Join(lib, "alias.go"))
+
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "main.go"), "package main\n", 0o644)
+	write(t, filepath.Join(dir, ".advncd", "stale.go"), "package stale\n", 0o644)
+
+	p := mustPack(t, dir, ArchiveOptions{Extra: []ExtraDir{{Dir: lib, Name: ".advncd/mod/lib"}}})
+	for _, name := range []string{"main.go", ".advncd/", ".advncd/mod/", ".advncd/mod/lib/", ".advncd/mod/lib/lib.go", ".advncd/mod/lib/.gitignore"} {
+		if _, ok := p.headers[name]; !ok {
+			t.Errorf("%s: not in archive", name)
+		}
+	}
+	for _, name := range []string{".advncd/stale.go", ".advncd/mod/lib/x.tmp", ".advncd/mod/lib/nested/", ".advncd/mod/lib/nested/go.mod"} {
+		if _, ok := p.headers[name]; ok {
+			t.Errorf("%s: packed, want left out", name)
+		}
+	}
+	if h := p.headers[".advncd/mod/lib/alias.go"]; h == nil || h.Linkname != "lib.go" {
+		t.Errorf("alias.go: want a link to lib.go, got %+v", h)
+	}
+}
+
+// TestArchiveModuleLayoutBuilds packs a service laid out for buildpacks
+// and runs what the Go buildpack runs on it: go build of GOOGLE_BUILDABLE
+// from the archive root.
+func TestArchiveModuleLayoutBuilds(t *testing.T) {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go not in PATH")
+	}
+	shared := map[string]string{
+		"shared/go.mod":    "module example.com/shared\n\ngo 1.22\n",
+		"shared/shared.go": "package shared\n\nconst Name = \"shared\"\n",
+	}
+	main := "package main\n\nimport \"example.com/shared\"\n\nfunc main() { println(shared.Name) }\n"
+	cases := []struct {
+		name   string
+		gowork string
+		files  map[string]string
+		pkg    string
+	}{
+		{
+			name:   "replace",
+			gowork: "off",
+			files: map[string]string{
+				"svc/go.mod":  "module example.com/svc\n\ngo 1.22\n\nrequire example.com/shared v0.0.0\n\nreplace example.com/shared => ../shared\n",
+				"svc/main.go": main,
+			},
+			pkg: "svc",
+		},
+		{
+			name: "workspace",
+			files: map[string]string{
+				"go.work":             "go 1.22\n\nuse (\n\t./svc\n\t./shared\n\t./tools\n)\n",
+				"svc/go.mod":          "module example.com/svc\n\ngo 1.22\n\nrequire example.com/shared v0.0.0\n",
+				"svc/cmd/api/main.go": main,
+				"tools/go.mod":        "module example.com/tools\n\ngo 1.22\n",
+			},
+			pkg: "svc/cmd/api",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Setenv("GOWORK", c.gowork)
+			repo := t.TempDir()
+			for rel, data := range shared {
+				write(t, filepath.Join(repo, filepath.FromSlash(rel)), data, 0o644)
+			}
+			for rel, data := range c.files {
+				write(t, filepath.Join(repo, filepath.FromSlash(rel)), data, 0o644)
+			}
+			l, err := gomod.Resolve(filepath.Join(repo, filepath.FromSlash(c.pkg)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if l, err = l.AtModule(); err != nil {
+				t.Fatal(err)
+			}
+			o := ArchiveOptions{Only: l.Dirs}
+			for _, g := range []struct {
+				name string
+				data []byte
+			}{{"go.work", l.GoWork}, {"go.mod", l.GoMod}} {
+				if g.data != nil {
+					o.Generated = append(o.Generated, GeneratedFile{Name: g.name, Data: g.data})
+				}
+			}
+			for _, e := range l.Extra {
+				o.Extra = append(o.Extra, ExtraDir{Dir: e.Dir, Name: e.Name})
+			}
+
+			out := t.TempDir()
+			extract(t, l.Root, o, out)
+			if _, err := os.Stat(filepath.Join(out, "go.mod")); err != nil {
+				t.Fatalf("no go.mod at the archive root: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(out, gomod.ModulesDir, "tools")); err == nil {
+				t.Error("tools packed, but nothing needs it")
+			}
+			buildable := l.Buildable()
+			if buildable == "" {
+				buildable = "."
+			}
+			cmd := exec.Command(goBin, "build", "-o", filepath.Join(t.TempDir(), "app"), buildable)
+			cmd.Dir = out
+			// As in the builder: no GOWORK or GOFLAGS from this machine,
+			// and nothing to download.
+			cmd.Env = append(os.Environ(), "GOWORK=", "GOFLAGS=", "GOPROXY=off", "GOTOOLCHAIN=local")
+			if b, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("go build %s: %v\n%s", buildable, err, b)
+			}
+		})
+	}
+}
+
+// extract packs dir and unpacks the archive into out.
+func extract(t *testing.T, dir string, o ArchiveOptions, out string) {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := writeTarGz(&buf, dir, o, nil); err != nil {
+		t.Fatal(err)
+	}
+	gr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := filepath.Join(out, filepath.FromSlash(hdr.Name))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(p, 0o755)
+		case tar.TypeSymlink:
+			err = os.Symlink(hdr.Linkname, p)
+		default:
+			var b []byte
+			if b, err = io.ReadAll(tr); err == nil {
+				err = os.WriteFile(p, b, 0o644)
+			}
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
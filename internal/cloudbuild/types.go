@@ -13,6 +13,30 @@ type SubmitRequest struct {
 	ProjectID   string
 	SourceDir   string
 	Image       string
+	Archive     ArchiveOptions
+	// Builder is BuilderBuildpacks (if empty) or BuilderDocker.
+	Builder string
+	// Buildable is the package buildpacks build, relative to SourceDir
+	// ("./svc/cmd/api"); empty builds SourceDir itself.
+	Buildable string
+	// Docker configures BuilderDocker builds.
+	Docker DockerOptions
+}
+
+// Builders of SubmitRequest.Builder.
+const (
+	BuilderBuildpacks = "buildpacks" // pack with Google Cloud's builder
+	BuilderDocker     = "docker"     // docker build with a Dockerfile
+)
+
+// Builders lists the accepted SubmitRequest.Builder values.
+var Builders = []string{BuilderBuildpacks, BuilderDocker}
+
+// DockerOptions are the docker build flags publish passes through.
+type DockerOptions struct {
+	Dockerfile string   // relative to SourceDir, slash-separated; "Dockerfile" if empty
+	BuildArgs  []string // KEY=VALUE
+	Target     string   // stage to build; the last one if empty
 }
 
 type WaitRequest struct {
@@ -21,4 +45,16 @@ type WaitRequest struct {
 	Region      string
 	BuildID     string
 	PollEvery   time.Duration
+	// OnStatus, if set, gets the status of a build still in progress
+	// (QUEUED, WORKING) on every poll.
+	OnStatus func(status string)
+}
+
+// Source is a source archive uploaded for Cloud Build.
+type Source struct {
+	Bucket string
+	Object string
+	Size   int64
+	Digest string // "sha256:<hex>" of the tar.gz, also in Object
+	Reused bool   // already in the bucket; nothing was uploaded
 }
\ No newline at end of file
@@ -55,6 +55,7 @@ func WaitBuild(ctx context.Context, req WaitRequest) (*Build, error) {
 
 			if res.StatusCode < 200 || res.StatusCode >= 300 {
 				return nil, apperr.New(ErrBuildPoll).
+					WithCause(apperr.HTTP(res.StatusCode, raw)).
 					WithMeta("http_status", res.Status).
 					WithMeta("raw_body", string(raw))
 			}
@@ -70,6 +71,9 @@ func WaitBuild(ctx context.Context, req WaitRequest) (*Build, error) {
 				return &Build{ID: out.ID, Status: out.Status, LogURL: out.LogURL}, nil
 			default:
 				// QUEUED, WORKING, etc.
+				if req.OnStatus != nil {
+					req.OnStatus(out.Status)
+				}
 			}
 		}
 	}
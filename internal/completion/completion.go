@@ -0,0 +1,204 @@
+package completion
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/cache"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/cloudbuild"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpcrm"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcprun"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/regions"
+)
+
+// Candidates for shell completion. Tab must answer at once and work
+// offline, so every list is served from the cache while it is fresh, then
+// fetched with a short timeout, and on any failure the last cached list is
+// used whatever its age. Errors are never reported: no candidates is the
+// worst case.
+//
+// Values are "value\tdescription", the form cobra passes to the shell.
+
+// fetchTimeout bounds the network part of one completion request.
+const fetchTimeout = 3 * time.Second
+
+const (
+	projectsTTL = 10 * time.Minute
+	regionsTTL  = 24 * time.Hour
+	servicesTTL = 5 * time.Minute
+	buildsTTL   = time.Minute
+)
+
+// projectsLimit keeps the completion list (and its fetch) small.
+const projectsLimit = 500
+
+// buildsLimit is how many recent builds are offered.
+const buildsLimit = 20
+
+func projectsKey() string                       { return "complete-projects" }
+func servicesKey(project, region string) string { return "complete-services-" + project + "-" + region }
+func buildsKey(project, region string) string   { return "complete-builds-" + project + "-" + region }
+
+// Projects returns active project IDs with their display names.
+func Projects() []string {
+	return cached(projectsKey(), projectsTTL, func(ctx context.Context, token string) ([]string, error) {
+		projects, _, err := gcpcrm.SearchProjects(ctx, token, gcpcrm.SearchQuery(""), projectsLimit)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]string, len(projects))
+		for i, p := range projects {
+			out[i] = candidate(p.ProjectID, p.DisplayName)
+		}
+		return out, nil
+	})
+}
+
+// Regions returns the Cloud Run regions of project, or the common ones
+// without a project or any cached list.
+func Regions(project string) []string {
+	var out []string
+	if project != "" {
+		out = cached("complete-regions-"+project, regionsTTL, func(ctx context.Context, token string) ([]string, error) {
+			available, err := regions.List(ctx, token, project)
+			if err != nil {
+				return nil, err
+			}
+			return regionCandidates(available), nil
+		})
+	}
+	if len(out) == 0 {
+		out = regionCandidates(regions.Common)
+	}
+	return out
+}
+
+// Services returns the Cloud Run service names in project/region.
+func Services(project, region string) []string {
+	if project == "" || region == "" {
+		return nil
+	}
+	return cached(servicesKey(project, region), servicesTTL, func(ctx context.Context, token string) ([]string, error) {
+		services, err := gcprun.ListServices(ctx, token, project, region)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]string, len(services))
+		for i, s := range services {
+			out[i] = candidate(s.Name, s.URI)
+		}
+		return out, nil
+	})
+}
+
+// Builds returns the IDs of recent builds in project/region, newest first.
+func Builds(project, region string) []string {
+	if project == "" || region == "" {
+		return nil
+	}
+	return cached(buildsKey(project, region), buildsTTL, func(ctx context.Context, token string) ([]string, error) {
+		builds, err := cloudbuild.ListBuilds(ctx, token, project, region, buildsLimit)
+		if err != nil {
+			return nil, err
+		}
+		return BuildCandidates(builds), nil
+	})
+}
+
+// SaveProjects refreshes the project candidates from a list the caller
+// already fetched (e.g. `gcp project list` without a filter).
+func SaveProjects(projects []gcpcrm.ProjectEntry) {
+	out := make([]string, len(projects))
+	for i, p := range projects {
+		out[i] = candidate(p.ProjectID, p.DisplayName)
+	}
+	_ = cache.Save(projectsKey(), out)
+}
+
+// SaveBuilds refreshes the build candidates from a fetched list.
+func SaveBuilds(project, region string, builds []cloudbuild.BuildInfo) {
+	_ = cache.Save(buildsKey(project, region), BuildCandidates(builds))
+}
+
+// RememberService adds a service just deployed to the cached list, so it
+// completes before the list expires.
+func RememberService(project, region, name, uri string) {
+	remember(servicesKey(project, region), candidate(name, uri))
+}
+
+// RememberBuild puts a build just submitted first in the cached list.
+func RememberBuild(project, region, id, status string) {
+	remember(buildsKey(project, region), candidate(id, status))
+}
+
+// BuildCandidates formats builds as "id\tstatus created".
+func BuildCandidates(builds []cloudbuild.BuildInfo) []string {
+	out := make([]string, len(builds))
+	for i, b := range builds {
+		out[i] = candidate(b.ID, strings.TrimSpace(b.Status+" "+b.CreateTime))
+	}
+	return out
+}
+
+func regionCandidates(available []regions.Region) []string {
+	out := make([]string, len(available))
+	for i, r := range available {
+		out[i] = candidate(r.ID, r.Name)
+	}
+	return out
+}
+
+// cached serves key from a fresh cache, else from fetch (saving the
+// result), else from a stale cache.
+func cached(key string, ttl time.Duration, fetch func(ctx context.Context, token string) ([]string, error)) []string {
+	var out []string
+	if cache.Load(key, ttl, &out) {
+		return out
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	if tb, err := auth.GetAccessToken(ctx); err == nil {
+		if got, err := fetch(ctx, tb.AccessToken); err == nil {
+			_ = cache.Save(key, got)
+			return got
+		}
+	}
+
+	if cache.LoadStale(key, &out) {
+		return out
+	}
+	return nil
+}
+
+// remember puts c first in the cached list under key, replacing an older
+// entry for the same value. The list's age is reset, which is fine for a
+// cache that only has to be roughly current.
+func remember(key, c string) {
+	var list []string
+	_ = cache.LoadStale(key, &list)
+	value := Value(c)
+	out := []string{c}
+	for _, old := range list {
+		if Value(old) != value {
+			out = append(out, old)
+		}
+	}
+	_ = cache.Save(key, out)
+}
+
+// Value strips the description from a candidate.
+func Value(c string) string {
+	v, _, _ := strings.Cut(c, "\t")
+	return v
+}
+
+func candidate(value, desc string) string {
+	desc = strings.Join(strings.Fields(desc), " ")
+	if desc == "" {
+		return value
+	}
+	return value + "\t" + desc
+}
@@ -0,0 +1,29 @@
+package config
+
+import "github.com/ADVNCD-Cloud/advncd-cli/internal/schema"
+
+// Schema history:
+//
+//	v1: either the flat file written by early `advncd init`, or the nested
+//	    layout from the design docs (~/.advncd/config.json):
+//	    {"version":1,"gcp":{"projectId":..,"region":..},"agent":{..},"dashboard":{..}}
+//	v2: flat {"version":2,"project_id":..,"region":..}
+var migrations = schema.Plan{
+	Current: CurrentVersion,
+	Migrations: []schema.Migration{
+		{From: 1, Apply: migrateV1},
+	},
+}
+
+func migrateV1(doc schema.Doc) error {
+	gcp := schema.Section(doc, "gcp")
+	schema.Move(doc, gcp, "projectId", "project_id")
+	schema.Move(doc, gcp, "region", "region")
+
+	// agent/dashboard settings belonged to the local agent that was never
+	// shipped; nothing reads them.
+	delete(doc, "gcp")
+	delete(doc, "agent")
+	delete(doc, "dashboard")
+	return nil
+}
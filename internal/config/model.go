@@ -1,7 +1,16 @@
 package config
 
+// CurrentVersion is the config schema written by this build.
+// Bump it together with a new entry in migrations (see migrate.go).
+const CurrentVersion = 2
+
 type Config struct {
 	Version   int    `json:"version"`
 	ProjectID string `json:"project_id"`
 	Region    string `json:"region"`
-}
\ No newline at end of file
+	// Lang is the CLI language (en, ru); empty means from the environment.
+	Lang string `json:"lang,omitempty"`
+	// Builder is how publish builds images (buildpacks, docker); empty
+	// means docker when the app has a Dockerfile, buildpacks otherwise.
+	Builder string `json:"builder,omitempty"`
+}
@@ -2,20 +2,44 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/schema"
 )
 
 var (
-	StoreReadFailed   = apperr.E("B-CONFIG-001", "Failed to read config")
-	StoreWriteFailed  = apperr.E("B-CONFIG-002", "Failed to write config")
-	StoreDeleteFailed = apperr.E("B-CONFIG-003", "Failed to delete config")
+	StoreReadFailed = apperr.E("B-CONFIG-001", "Failed to read config",
+		apperr.WithSummary("The config file exists but could not be read or parsed."),
+		apperr.WithDocs("README.md, 2.1 Config"))
+	StoreWriteFailed = apperr.E("B-CONFIG-002", "Failed to write config",
+		apperr.WithSummary("The config file could not be written."),
+		apperr.WithDocs("README.md, 2.1 Config"))
+	StoreDeleteFailed = apperr.E("B-CONFIG-003", "Failed to delete config",
+		apperr.WithSummary("The config file could not be deleted."))
+	StoreTooNew = apperr.E("B-CONFIG-004", "Config was written by a newer version of advncd",
+		apperr.WithSummary("The config file has a newer schema version than this advncd understands."),
+		apperr.WithDocs("README.md, 2.2.1 schema versions"))
+	StoreMigrateFailed = apperr.E("B-CONFIG-005", "Failed to upgrade config to the current format",
+		apperr.WithSummary("An older config file could not be upgraded to the current schema."),
+		apperr.WithDocs("README.md, 2.2.1 schema versions"))
+	ErrNotSet = apperr.E("B-CONFIG-006", "Project and region are not set",
+		apperr.WithSeverity(apperr.SeverityWarn),
+		apperr.WithTitle("GCP project or region not set"),
+		apperr.WithSummary("Set a default project and region to deploy and query resources."))
+	ErrInvalidValue = apperr.E("B-CONFIG-007", "Invalid config value",
+		apperr.WithSummary("A field in the config file has a value this advncd doesn't accept."),
+		apperr.WithDocs("README.md, 2.1 Config"))
 )
 
 type Store struct {
 	Path string
+	// LegacyPath is read (never written) when Path does not exist yet:
+	// ~/.advncd/config.json from the original design docs.
+	LegacyPath string
 }
 
 func DefaultStore() (*Store, error) {
@@ -25,7 +49,11 @@ func DefaultStore() (*Store, error) {
 			WithFix("Unable to resolve user config dir.")
 	}
 	base := filepath.Join(dir, "advncd")
-	return &Store{Path: filepath.Join(base, "config.json")}, nil
+	s := &Store{Path: filepath.Join(base, "config.json")}
+	if home, err := os.UserHomeDir(); err == nil {
+		s.LegacyPath = filepath.Join(home, ".advncd", "config.json")
+	}
+	return s, nil
 }
 
 func (s *Store) EnsureDir() error {
@@ -41,6 +69,7 @@ func (s *Store) Save(c Config) error {
 		return err
 	}
 
+	c.Version = CurrentVersion
 	b, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
 		return apperr.New(StoreWriteFailed).WithCause(err)
@@ -53,21 +82,100 @@ func (s *Store) Save(c Config) error {
 	return nil
 }
 
+// Load reads the config, upgrading older formats in place (the original
+// file is kept as config.json.v<N>.bak). Returns nil, nil if no config exists.
+// A file whose upgrade only bumps the version is left as it is.
 func (s *Store) Load() (*Config, error) {
-	b, err := os.ReadFile(s.Path)
+	l, err := s.read()
+	if err != nil || l == nil {
+		return nil, err
+	}
+	if l.src == s.Path && !l.changed {
+		return &l.cfg, nil
+	}
+
+	// Upgraded or imported from the legacy location: persist the new format.
+	if l.src == s.Path {
+		if _, err := schema.Backup(s.Path, l.raw, l.from); err != nil {
+			return nil, apperr.New(StoreMigrateFailed).WithCause(err).
+				WithMeta("path", s.Path).
+				WithFix("Check filesystem permissions.")
+		}
+	}
+	if err := s.Save(l.cfg); err != nil {
+		return nil, err
+	}
+	if l.src != s.Path {
+		_ = schema.Retire(l.src)
+	}
+	return &l.cfg, nil
+}
+
+// Peek reads the config like Load but never writes: an older format is
+// upgraded in memory only. It is for lookups that must not migrate files
+// as a side effect: the message language, read before every command, and
+// shell completion, run on every Tab.
+func (s *Store) Peek() (*Config, error) {
+	l, err := s.read()
+	if err != nil || l == nil {
+		return nil, err
+	}
+	return &l.cfg, nil
+}
+
+// loaded is a config file read and upgraded in memory.
+type loaded struct {
+	cfg  Config
+	raw  []byte // the file as read
+	src  string // path it was read from
+	from int    // its schema version
+	// changed is false when the upgrade altered nothing but the version
+	// (a flat v1 file), so the file needs no rewrite.
+	changed bool
+}
+
+// read returns nil, nil if no config exists.
+func (s *Store) read() (*loaded, error) {
+	b, src, err := schema.ReadFirst(s.Path, s.LegacyPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
-		return nil, apperr.New(StoreReadFailed).WithCause(err)
+		return nil, apperr.New(StoreReadFailed).WithCause(err).
+			WithMeta("path", src)
 	}
 
-	var c Config
-	if err := json.Unmarshal(b, &c); err != nil {
+	doc, err := schema.Decode(b)
+	if err != nil {
 		return nil, apperr.New(StoreReadFailed).WithCause(err).
+			WithMeta("path", src).
 			WithFix("Config file is corrupted; re-run: advncd init")
 	}
-	return &c, nil
+	orig, _ := schema.Decode(b)
+
+	from, err := migrations.Upgrade(doc)
+	if err != nil {
+		var tooNew *schema.TooNewError
+		if errors.As(err, &tooNew) {
+			return nil, apperr.New(StoreTooNew).
+				WithMeta("path", src).
+				WithMeta("file_version", strconv.Itoa(tooNew.Found)).
+				WithMeta("supported_version", strconv.Itoa(tooNew.Supported)).
+				WithFix("Upgrade advncd to the latest release.").
+				WithFix("Or move the file aside and re-run: advncd init")
+		}
+		return nil, apperr.New(StoreMigrateFailed).WithCause(err).
+			WithMeta("path", src).
+			WithFix("Move the file aside and re-run: advncd init")
+	}
+
+	l := &loaded{raw: b, src: src, from: from, changed: schema.Changed(orig, doc)}
+	if err := schema.Reencode(doc, &l.cfg); err != nil {
+		return nil, apperr.New(StoreReadFailed).WithCause(err).
+			WithMeta("path", src).
+			WithFix("Config file is corrupted; re-run: advncd init")
+	}
+	return l, nil
 }
 
 func (s *Store) Delete() error {
@@ -78,4 +186,4 @@ func (s *Store) Delete() error {
 		return apperr.New(StoreDeleteFailed).WithCause(err)
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testStore(t *testing.T) *Store {
+	t.Helper()
+	dir := t.TempDir()
+	return &Store{
+		Path:       filepath.Join(dir, "advncd", "config.json"),
+		LegacyPath: filepath.Join(dir, ".advncd", "config.json"),
+	}
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestLoadMissing(t *testing.T) {
+	s := testStore(t)
+	cfg, err := s.Load()
+	if cfg != nil || err != nil {
+		t.Fatalf("Load() = %v, %v; want nil, nil", cfg, err)
+	}
+}
+
+func TestLoadNestedV1MigratesWithBackup(t *testing.T) {
+	s := testStore(t)
+	orig := `{"version":1,"gcp":{"projectId":"p1","region":"europe-west1"},"agent":{"port":1}}`
+	writeFile(t, s.Path, orig)
+
+	cfg, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ProjectID != "p1" || cfg.Region != "europe-west1" {
+		t.Errorf("Load() = %+v", cfg)
+	}
+	if got := readFile(t, s.Path+".v1.bak"); got != orig {
+		t.Errorf("backup = %s, want the original", got)
+	}
+	again, err := s.Peek()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Version != CurrentVersion || again.ProjectID != "p1" {
+		t.Errorf("rewritten config = %+v", again)
+	}
+}
+
+func TestLoadFlatV1Untouched(t *testing.T) {
+	s := testStore(t)
+	orig := `{"project_id":"p1","region":"us-central1"}`
+	writeFile(t, s.Path, orig)
+
+	cfg, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ProjectID != "p1" || cfg.Region != "us-central1" {
+		t.Errorf("Load() = %+v", cfg)
+	}
+	if got := readFile(t, s.Path); got != orig {
+		t.Errorf("config rewritten to %s", got)
+	}
+	if exists(s.Path + ".v1.bak") {
+		t.Error("backup written for a version-only upgrade")
+	}
+}
+
+func TestPeekNeverWrites(t *testing.T) {
+	s := testStore(t)
+	orig := `{"version":1,"gcp":{"projectId":"p1","region":"europe-west1"}}`
+	writeFile(t, s.Path, orig)
+	writeFile(t, s.LegacyPath, `{"gcp":{"projectId":"legacy"}}`)
+
+	cfg, err := s.Peek()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ProjectID != "p1" {
+		t.Errorf("Peek() = %+v", cfg)
+	}
+	if got := readFile(t, s.Path); got != orig {
+		t.Errorf("config rewritten to %s", got)
+	}
+	if exists(s.Path+".v1.bak") || !exists(s.LegacyPath) {
+		t.Error("Peek wrote a backup or retired the legacy file")
+	}
+}
+
+func TestLoadImportsLegacy(t *testing.T) {
+	s := testStore(t)
+	writeFile(t, s.LegacyPath, `{"version":1,"gcp":{"projectId":"p1","region":"asia-east1"}}`)
+
+	cfg, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ProjectID != "p1" || cfg.Region != "asia-east1" {
+		t.Errorf("Load() = %+v", cfg)
+	}
+	if !exists(s.Path) {
+		t.Error("config not written to the new location")
+	}
+	if exists(s.LegacyPath) || !exists(s.LegacyPath+".migrated") {
+		t.Error("legacy file not retired")
+	}
+	if exists(s.Path + ".v1.bak") {
+		t.Error("backup written for an imported legacy file")
+	}
+}
+
+func TestLoadTooNew(t *testing.T) {
+	s := testStore(t)
+	writeFile(t, s.Path, `{"version":99}`)
+	if _, err := s.Load(); !errors.Is(err, StoreTooNew) {
+		t.Fatalf("Load() error = %v, want %v", err, StoreTooNew)
+	}
+	if _, err := s.Peek(); !errors.Is(err, StoreTooNew) {
+		t.Fatalf("Peek() error = %v, want %v", err, StoreTooNew)
+	}
+}
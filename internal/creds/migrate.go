@@ -0,0 +1,33 @@
+package creds
+
+import "github.com/ADVNCD-Cloud/advncd-cli/internal/schema"
+
+// Schema history:
+//
+//	v1: either the flat file written by early `advncd login`, or the nested
+//	    layout from the design docs (~/.advncd/credentials.json):
+//	    {"version":1,"gcp":{"clientId":..,"refreshToken":..,"accessTokenExpiresAt":..,..}}
+//	v2: flat, see Credentials.
+var migrations = schema.Plan{
+	Current: CurrentVersion,
+	Migrations: []schema.Migration{
+		{From: 1, Apply: migrateV1},
+	},
+}
+
+func migrateV1(doc schema.Doc) error {
+	gcp := schema.Section(doc, "gcp")
+	schema.Move(doc, gcp, "clientId", "client_id")
+	schema.Move(doc, gcp, "scopes", "scopes")
+	schema.Move(doc, gcp, "refreshToken", "refresh_token")
+	schema.Move(doc, gcp, "accessToken", "access_token")
+	schema.Move(doc, gcp, "accessTokenExpiresAt", "expiry")
+	schema.Move(doc, gcp, "userEmail", "email")
+	delete(doc, "gcp")
+
+	// The nested layout never recorded a token type; Google always issues Bearer.
+	if _, ok := doc["token_type"]; !ok && doc["access_token"] != nil {
+		doc["token_type"] = "Bearer"
+	}
+	return nil
+}
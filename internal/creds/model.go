@@ -2,6 +2,10 @@ package creds
 
 import "time"
 
+// CurrentVersion is the credentials schema written by this build.
+// Bump it together with a new entry in migrations (see migrate.go).
+const CurrentVersion = 2
+
 type Credentials struct {
 	Version int `json:"version"`
 
@@ -14,4 +18,4 @@ type Credentials struct {
 	RefreshToken string    `json:"refresh_token"`
 	Expiry       time.Time `json:"expiry"`
 	TokenType    string    `json:"token_type"`
-}
\ No newline at end of file
+}
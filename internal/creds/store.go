@@ -2,20 +2,37 @@ package creds
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/schema"
 )
 
 var (
-	StoreReadFailed  = apperr.E("A-CREDS-001", "Failed to read credentials")
-	StoreWriteFailed = apperr.E("A-CREDS-002", "Failed to write credentials")
-	StoreDeleteFailed = apperr.E("A-CREDS-003", "Failed to delete credentials")
+	StoreReadFailed = apperr.E("A-CREDS-001", "Failed to read credentials",
+		apperr.WithSummary("The credentials file exists but could not be read or parsed."),
+		apperr.WithDocs("README.md, 2.2 Credentials"))
+	StoreWriteFailed = apperr.E("A-CREDS-002", "Failed to write credentials",
+		apperr.WithSummary("The credentials file could not be written."),
+		apperr.WithDocs("README.md, 2.2 Credentials"))
+	StoreDeleteFailed = apperr.E("A-CREDS-003", "Failed to delete credentials",
+		apperr.WithSummary("The credentials file could not be deleted."))
+	StoreTooNew = apperr.E("A-CREDS-004", "Credentials were written by a newer version of advncd",
+		apperr.WithSummary("The credentials file has a newer schema version than this advncd understands."),
+		apperr.WithDocs("README.md, 2.2.1 schema versions"))
+	StoreMigrateFailed = apperr.E("A-CREDS-005", "Failed to upgrade credentials to the current format",
+		apperr.WithSummary("An older credentials file could not be upgraded to the current schema."),
+		apperr.WithDocs("README.md, 2.2.1 schema versions"))
 )
 
 type Store struct {
 	Path string
+	// LegacyPath is read (never written) when Path does not exist yet:
+	// ~/.advncd/credentials.json from the original design docs.
+	LegacyPath string
 }
 
 func DefaultStore() (*Store, error) {
@@ -25,7 +42,11 @@ func DefaultStore() (*Store, error) {
 			WithFix("Unable to resolve user config dir.")
 	}
 	base := filepath.Join(dir, "advncd")
-	return &Store{Path: filepath.Join(base, "credentials.json")}, nil
+	s := &Store{Path: filepath.Join(base, "credentials.json")}
+	if home, err := os.UserHomeDir(); err == nil {
+		s.LegacyPath = filepath.Join(home, ".advncd", "credentials.json")
+	}
+	return s, nil
 }
 
 func (s *Store) EnsureDir() error {
@@ -41,6 +62,7 @@ func (s *Store) Save(c Credentials) error {
 		return err
 	}
 
+	c.Version = CurrentVersion
 	b, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
 		return apperr.New(StoreWriteFailed).WithCause(err)
@@ -54,21 +76,70 @@ func (s *Store) Save(c Credentials) error {
 	return nil
 }
 
+// Load reads credentials, upgrading older formats in place (the original
+// file is kept as credentials.json.v<N>.bak, mode 0600).
+// Returns nil, nil if the user never logged in.
+// A file whose upgrade only bumps the version is left as it is.
 func (s *Store) Load() (*Credentials, error) {
-	b, err := os.ReadFile(s.Path)
+	b, src, err := schema.ReadFirst(s.Path, s.LegacyPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
 		return nil, apperr.New(StoreReadFailed).WithCause(err).
+			WithMeta("path", src).
 			WithFix("Check filesystem permissions.")
 	}
 
+	doc, err := schema.Decode(b)
+	if err != nil {
+		return nil, apperr.New(StoreReadFailed).WithCause(err).
+			WithMeta("path", src).
+			WithFix("Credentials file is corrupted; try 'advncd logout' and login again.")
+	}
+	orig, _ := schema.Decode(b)
+
+	from, err := migrations.Upgrade(doc)
+	if err != nil {
+		var tooNew *schema.TooNewError
+		if errors.As(err, &tooNew) {
+			return nil, apperr.New(StoreTooNew).
+				WithMeta("path", src).
+				WithMeta("file_version", strconv.Itoa(tooNew.Found)).
+				WithMeta("supported_version", strconv.Itoa(tooNew.Supported)).
+				WithFix("Upgrade advncd to the latest release.").
+				WithFix("Or run 'advncd logout' and login again with this version.")
+		}
+		return nil, apperr.New(StoreMigrateFailed).WithCause(err).
+			WithMeta("path", src).
+			WithFix("Run 'advncd logout' and login again.")
+	}
+
 	var c Credentials
-	if err := json.Unmarshal(b, &c); err != nil {
+	if err := schema.Reencode(doc, &c); err != nil {
 		return nil, apperr.New(StoreReadFailed).WithCause(err).
+			WithMeta("path", src).
 			WithFix("Credentials file is corrupted; try 'advncd logout' and login again.")
 	}
+
+	if src == s.Path && !schema.Changed(orig, doc) {
+		return &c, nil
+	}
+
+	// Upgraded or imported from the legacy location: persist the new format.
+	if src == s.Path {
+		if _, err := schema.Backup(s.Path, b, from); err != nil {
+			return nil, apperr.New(StoreMigrateFailed).WithCause(err).
+				WithMeta("path", s.Path).
+				WithFix("Check filesystem permissions.")
+		}
+	}
+	if err := s.Save(c); err != nil {
+		return nil, err
+	}
+	if src != s.Path {
+		_ = schema.Retire(src)
+	}
 	return &c, nil
 }
 
@@ -80,4 +151,4 @@ func (s *Store) Delete() error {
 		return apperr.New(StoreDeleteFailed).WithCause(err)
 	}
 	return nil
-}
\ No newline at end of file
+}
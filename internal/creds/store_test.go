@@ -0,0 +1,99 @@
+package creds
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testStore(t *testing.T) *Store {
+	t.Helper()
+	dir := t.TempDir()
+	return &Store{
+		Path:       filepath.Join(dir, "advncd", "credentials.json"),
+		LegacyPath: filepath.Join(dir, ".advncd", "credentials.json"),
+	}
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestLoadNestedV1MigratesWithBackup(t *testing.T) {
+	s := testStore(t)
+	orig := `{"version":1,"gcp":{"clientId":"c","refreshToken":"r","accessToken":"a","userEmail":"u@example.com"}}`
+	writeFile(t, s.Path, orig)
+
+	c, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.RefreshToken != "r" || c.Email != "u@example.com" || c.TokenType != "Bearer" {
+		t.Errorf("Load() = %+v", c)
+	}
+	if got := readFile(t, s.Path+".v1.bak"); got != orig {
+		t.Errorf("backup = %s, want the original", got)
+	}
+	if got := readFile(t, s.Path); got == orig {
+		t.Error("credentials not rewritten in the current format")
+	}
+}
+
+func TestLoadFlatV1Untouched(t *testing.T) {
+	s := testStore(t)
+	orig := `{"email":"u@example.com","client_id":"c","access_token":"a","refresh_token":"r","token_type":"Bearer"}`
+	writeFile(t, s.Path, orig)
+
+	c, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.RefreshToken != "r" || c.Email != "u@example.com" {
+		t.Errorf("Load() = %+v", c)
+	}
+	if got := readFile(t, s.Path); got != orig {
+		t.Errorf("credentials rewritten to %s", got)
+	}
+	if exists(s.Path + ".v1.bak") {
+		t.Error("backup written for a version-only upgrade")
+	}
+}
+
+func TestLoadImportsLegacy(t *testing.T) {
+	s := testStore(t)
+	writeFile(t, s.LegacyPath, `{"version":1,"gcp":{"refreshToken":"r"}}`)
+
+	c, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.RefreshToken != "r" {
+		t.Errorf("Load() = %+v", c)
+	}
+	if !exists(s.Path) {
+		t.Error("credentials not written to the new location")
+	}
+	if exists(s.LegacyPath) || !exists(s.LegacyPath+".migrated") {
+		t.Error("legacy file not retired")
+	}
+}
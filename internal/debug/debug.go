@@ -0,0 +1,23 @@
+// Package debug is a tiny opt-in log for troubleshooting (ADVNCD_DEBUG=1).
+// Everything written is passed through redact first.
+package debug
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/redact"
+)
+
+// Enabled turns logging on; set from ADVNCD_DEBUG at startup.
+var Enabled = os.Getenv("ADVNCD_DEBUG") != ""
+
+// Logf writes a timestamped, redacted line to stderr when enabled.
+func Logf(format string, args ...any) {
+	if !Enabled {
+		return
+	}
+	msg := redact.String(fmt.Sprintf(format, args...))
+	fmt.Fprintf(os.Stderr, "debug %s %s\n", time.Now().Format("15:04:05.000"), msg)
+}
@@ -13,12 +13,14 @@ import (
 )
 
 var (
-	ErrRepoCheck  = apperr.E("C-AR-001", "Failed to check Artifact Registry repository")
-	ErrRepoCreate = apperr.E("C-AR-002", "Failed to create Artifact Registry repository")
+	ErrRepoCheck = apperr.E("C-AR-001", "Failed to check Artifact Registry repository",
+		apperr.WithSummary("The Artifact Registry repository could not be looked up."))
+	ErrRepoCreate = apperr.E("C-AR-002", "Failed to create Artifact Registry repository",
+		apperr.WithSummary("The Artifact Registry repository could not be created."))
 )
 
 func EnsureDockerRepo(ctx context.Context, accessToken, projectID, region, repoID string) error {
-	exists, err := repoExists(ctx, accessToken, projectID, region, repoID)
+	exists, err := RepoExists(ctx, accessToken, projectID, region, repoID)
 	if err != nil {
 		return err
 	}
@@ -28,7 +30,8 @@ func EnsureDockerRepo(ctx context.Context, accessToken, projectID, region, repoI
 	return createRepo(ctx, accessToken, projectID, region, repoID)
 }
 
-func repoExists(ctx context.Context, accessToken, projectID, region, repoID string) (bool, error) {
+// RepoExists reports whether the repository exists (false on 404).
+func RepoExists(ctx context.Context, accessToken, projectID, region, repoID string) (bool, error) {
 	u := fmt.Sprintf("https://artifactregistry.googleapis.com/v1/projects/%s/locations/%s/repositories/%s", projectID, region, repoID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
@@ -52,6 +55,7 @@ func repoExists(ctx context.Context, accessToken, projectID, region, repoID stri
 	}
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		return false, apperr.New(ErrRepoCheck).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
 			WithMeta("http_status", res.Status).
 			WithMeta("raw_body", string(raw)).
 			WithFix("Ensure Artifact Registry API is enabled and you have permission to view repositories.")
@@ -92,6 +96,7 @@ func createRepo(ctx context.Context, accessToken, projectID, region, repoID stri
 	}
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		return apperr.New(ErrRepoCreate).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
 			WithMeta("http_status", res.Status).
 			WithMeta("raw_body", string(raw)).
 			WithFix("Ensure you have permission to create Artifact Registry repositories (roles/artifactregistry.admin or owner in dev).")
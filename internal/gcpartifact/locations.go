@@ -0,0 +1,78 @@
+package gcpartifact
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var ErrLocations = apperr.E("C-AR-003", "Failed to list Artifact Registry locations",
+	apperr.WithSummary("Artifact Registry did not return its locations."))
+
+type locationsResp struct {
+	Locations []struct {
+		LocationID string `json:"locationId"`
+	} `json:"locations"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// ListLocations returns the region IDs where Artifact Registry repositories can be created.
+func ListLocations(ctx context.Context, accessToken, projectID string) ([]string, error) {
+	var all []string
+	pageToken := ""
+
+	client := &http.Client{Timeout: 20 * time.Second}
+
+	for {
+		u, _ := url.Parse(fmt.Sprintf("https://artifactregistry.googleapis.com/v1/projects/%s/locations", projectID))
+		q := u.Query()
+		if pageToken != "" {
+			q.Set("pageToken", pageToken)
+		}
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, apperr.New(ErrLocations).WithCause(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, apperr.New(ErrLocations).WithCause(err).
+				WithFix("Check your internet connection and try again.")
+		}
+		raw, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return nil, apperr.New(ErrLocations).
+				WithCause(apperr.HTTP(res.StatusCode, raw)).
+				WithMeta("http_status", res.Status).
+				WithMeta("raw_body", string(raw)).
+				WithFix("Ensure Artifact Registry API is enabled for this project.")
+		}
+
+		var out locationsResp
+		if err := json.Unmarshal(raw, &out); err != nil {
+			return nil, apperr.New(ErrLocations).WithCause(err).
+				WithMeta("raw_body", string(raw))
+		}
+		for _, l := range out.Locations {
+			all = append(all, l.LocationID)
+		}
+
+		if out.NextPageToken == "" {
+			break
+		}
+		pageToken = out.NextPageToken
+	}
+
+	return all, nil
+}
@@ -0,0 +1,77 @@
+package gcpbilling
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var (
+	ErrBillingLink = apperr.E("B-BILLING-001", "Failed to link billing account",
+		apperr.WithSummary("Linking the billing account to the project was rejected."),
+		apperr.WithDocs("GCP Console → Billing"))
+)
+
+type BillingInfo struct {
+	Name               string `json:"name"`
+	ProjectID          string `json:"projectId"`
+	BillingAccountName string `json:"billingAccountName"` // billingAccounts/XXXXXX-XXXXXX-XXXXXX
+	BillingEnabled     bool   `json:"billingEnabled"`
+}
+
+// AccountName normalizes "XXXXXX-XXXXXX-XXXXXX" or "billingAccounts/XXX" to the resource name.
+func AccountName(id string) string {
+	id = strings.TrimSpace(id)
+	if strings.HasPrefix(id, "billingAccounts/") {
+		return id
+	}
+	return "billingAccounts/" + id
+}
+
+// LinkBillingAccount attaches the project to a billing account.
+func LinkBillingAccount(ctx context.Context, accessToken, projectID, account string) (*BillingInfo, error) {
+	u := fmt.Sprintf("https://cloudbilling.googleapis.com/v1/projects/%s/billingInfo", projectID)
+
+	b, _ := json.Marshal(map[string]string{"billingAccountName": AccountName(account)})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, apperr.New(ErrBillingLink).WithCause(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, apperr.New(ErrBillingLink).WithCause(err).
+			WithFix("Check your internet connection and try again.")
+	}
+	defer res.Body.Close()
+
+	raw, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, apperr.New(ErrBillingLink).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
+			WithMeta("http_status", res.Status).
+			WithMeta("project_id", projectID).
+			WithMeta("billing_account", AccountName(account)).
+			WithMeta("raw_body", string(raw)).
+			WithFix("Linking needs billing.resourceAssociations.create on the account (roles/billing.user) and resourcemanager.projects.createBillingAssignment on the project.")
+	}
+
+	var out BillingInfo
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, apperr.New(ErrBillingLink).WithCause(err).
+			WithMeta("raw_body", string(raw))
+	}
+	return &out, nil
+}
@@ -0,0 +1,156 @@
+package gcpbilling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var (
+	ErrBillingDisabled = apperr.E("B-BILLING-002", "Billing is not enabled for this project",
+		apperr.WithTitle("Billing disabled"),
+		apperr.WithSummary("Cloud Build, Artifact Registry and Cloud Run refuse to work without billing."),
+		apperr.WithDocs("GCP Console → Billing"))
+	ErrBillingCheck = apperr.E("B-BILLING-003", "Failed to check project billing status",
+		apperr.WithSeverity(apperr.SeverityWarn),
+		apperr.WithSummary("The project's billing status could not be read."))
+	ErrBillingAccounts = apperr.E("B-BILLING-004", "Failed to list billing accounts",
+		apperr.WithSummary("The billing accounts visible to you could not be listed."))
+)
+
+type Account struct {
+	Name        string `json:"name"` // billingAccounts/XXXXXX-XXXXXX-XXXXXX
+	DisplayName string `json:"displayName"`
+	Open        bool   `json:"open"`
+}
+
+// ID returns the bare account ID (XXXXXX-XXXXXX-XXXXXX).
+func (a Account) ID() string {
+	return strings.TrimPrefix(a.Name, "billingAccounts/")
+}
+
+type accountsResp struct {
+	BillingAccounts []Account `json:"billingAccounts"`
+	NextPageToken   string    `json:"nextPageToken"`
+}
+
+// GetBillingInfo returns whether billing is enabled for the project.
+func GetBillingInfo(ctx context.Context, accessToken, projectID string) (*BillingInfo, error) {
+	u := fmt.Sprintf("https://cloudbilling.googleapis.com/v1/projects/%s/billingInfo", projectID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, apperr.New(ErrBillingCheck).WithCause(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, apperr.New(ErrBillingCheck).WithCause(err).
+			WithFix("Check your internet connection and try again.")
+	}
+	defer res.Body.Close()
+
+	raw, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, apperr.New(ErrBillingCheck).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
+			WithMeta("http_status", res.Status).
+			WithMeta("project_id", projectID).
+			WithMeta("raw_body", string(raw)).
+			WithFix("Ensure you have resourcemanager.projects.get on this project.")
+	}
+
+	var out BillingInfo
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, apperr.New(ErrBillingCheck).WithCause(err).
+			WithMeta("raw_body", string(raw))
+	}
+	return &out, nil
+}
+
+// ListAccounts returns billing accounts visible to the user.
+func ListAccounts(ctx context.Context, accessToken string) ([]Account, error) {
+	var all []Account
+	pageToken := ""
+
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	for {
+		u, _ := url.Parse("https://cloudbilling.googleapis.com/v1/billingAccounts")
+		q := u.Query()
+		if pageToken != "" {
+			q.Set("pageToken", pageToken)
+		}
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, apperr.New(ErrBillingAccounts).WithCause(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, apperr.New(ErrBillingAccounts).WithCause(err).
+				WithFix("Check your internet connection and try again.")
+		}
+		raw, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return nil, apperr.New(ErrBillingAccounts).
+				WithCause(apperr.HTTP(res.StatusCode, raw)).
+				WithMeta("http_status", res.Status).
+				WithMeta("raw_body", string(raw))
+		}
+
+		var out accountsResp
+		if err := json.Unmarshal(raw, &out); err != nil {
+			return nil, apperr.New(ErrBillingAccounts).WithCause(err).
+				WithMeta("raw_body", string(raw))
+		}
+		all = append(all, out.BillingAccounts...)
+
+		if out.NextPageToken == "" {
+			break
+		}
+		pageToken = out.NextPageToken
+	}
+
+	return all, nil
+}
+
+// DisabledError describes a project without billing, with one fix per open
+// billing account the user can link (best-effort: listing may be forbidden).
+func DisabledError(ctx context.Context, accessToken, projectID string) *apperr.Error {
+	ae := apperr.New(ErrBillingDisabled).
+		WithMeta("project_id", projectID).
+		WithFix("Cloud Build and Artifact Registry fail without billing; link a billing account first.")
+
+	accounts, err := ListAccounts(ctx, accessToken)
+	open := 0
+	if err == nil {
+		for _, a := range accounts {
+			if !a.Open {
+				continue
+			}
+			open++
+			ae = ae.WithFix(fmt.Sprintf("Link %q: advncd gcp billing link %s", a.DisplayName, a.ID()))
+		}
+	}
+	if open == 0 {
+		ae = ae.WithFix("No open billing account is visible to you; ask a billing admin to link one, or to grant you roles/billing.user.").
+			WithFix("Then run: advncd gcp billing link <BILLING_ACCOUNT_ID>")
+	}
+	return ae
+}
@@ -0,0 +1,74 @@
+package gcpcrm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type parentNode struct {
+	DisplayName string `json:"displayName"`
+	Parent      string `json:"parent"`
+}
+
+// ResolvePaths fills ProjectEntry.Path with "org / folder / ... " names.
+// Lookups are shared across projects; anything we can't read (no
+// resourcemanager.folders.get, deleted folder) is shown by resource name.
+func ResolvePaths(ctx context.Context, accessToken string, projects []ProjectEntry) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	memo := map[string]parentNode{}
+
+	lookup := func(name string) parentNode {
+		if n, ok := memo[name]; ok {
+			return n
+		}
+		n := fetchParent(ctx, client, accessToken, name)
+		memo[name] = n
+		return n
+	}
+
+	for i := range projects {
+		var parts []string
+		name := projects[i].Parent
+		// Folders nest at most 10 deep; the bound guards against cycles.
+		for depth := 0; name != "" && depth < 12; depth++ {
+			n := lookup(name)
+			parts = append([]string{n.DisplayName}, parts...)
+			name = n.Parent
+		}
+		projects[i].Path = strings.Join(parts, " / ")
+	}
+}
+
+func fetchParent(ctx context.Context, client *http.Client, accessToken, name string) parentNode {
+	fallback := parentNode{DisplayName: name}
+	if !strings.HasPrefix(name, "folders/") && !strings.HasPrefix(name, "organizations/") {
+		return fallback
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://cloudresourcemanager.googleapis.com/v3/"+name, nil)
+	if err != nil {
+		return fallback
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return fallback
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fallback
+	}
+
+	var out parentNode
+	if err := json.Unmarshal(body, &out); err != nil || out.DisplayName == "" {
+		return fallback
+	}
+	return out
+}
@@ -0,0 +1,169 @@
+package gcpcrm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var (
+	ErrProjectCreate = apperr.E("B-CRM-004", "Failed to create GCP project",
+		apperr.WithSummary("Resource Manager rejected the new project."))
+	ErrProjectOp = apperr.E("B-CRM-005", "Failed to wait for project operation",
+		apperr.WithSummary("The project creation operation failed or did not finish."))
+	ErrProjectIDInvalid = apperr.E("B-CRM-006", "Invalid GCP project ID",
+		apperr.WithSummary("Project IDs are 6-30 lowercase letters, digits or hyphens, starting with a letter."))
+)
+
+// Project IDs: 6-30 chars, lowercase letters, digits and hyphens,
+// starting with a letter and not ending with a hyphen.
+var projectIDPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+
+type CreateProjectRequest struct {
+	ProjectID   string
+	DisplayName string
+	// Parent is "folders/{id}", "organizations/{id}" or empty (no organization).
+	Parent string
+}
+
+type crmOperation struct {
+	Name  string `json:"name"`
+	Done  bool   `json:"done"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// ValidateProjectID checks the ID format before asking Google.
+func ValidateProjectID(id string) error {
+	if projectIDPattern.MatchString(id) {
+		return nil
+	}
+	return apperr.New(ErrProjectIDInvalid).
+		WithMeta("project_id", id).
+		WithFix("Use 6-30 lowercase letters, digits or hyphens; start with a letter, don't end with a hyphen.")
+}
+
+// CreateProject creates the project and waits for the long-running operation.
+func CreateProject(ctx context.Context, accessToken string, req CreateProjectRequest) error {
+	if err := ValidateProjectID(req.ProjectID); err != nil {
+		return err
+	}
+
+	body := map[string]string{"projectId": req.ProjectID}
+	if req.DisplayName != "" {
+		body["displayName"] = req.DisplayName
+	}
+	if req.Parent != "" {
+		body["parent"] = req.Parent
+	}
+	b, _ := json.Marshal(body)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://cloudresourcemanager.googleapis.com/v3/projects", bytes.NewReader(b))
+	if err != nil {
+		return apperr.New(ErrProjectCreate).WithCause(err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+accessToken)
+	httpReq.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	res, err := client.Do(httpReq)
+	if err != nil {
+		return apperr.New(ErrProjectCreate).WithCause(err).
+			WithFix("Check your internet connection and try again.")
+	}
+	defer res.Body.Close()
+
+	raw, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode == 409 {
+		return apperr.New(ErrProjectCreate).
+			WithMeta("http_status", res.Status).
+			WithMeta("project_id", req.ProjectID).
+			WithFix("Project IDs are globally unique (and stay reserved for 30 days after deletion); pick another ID.")
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return apperr.New(ErrProjectCreate).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
+			WithMeta("http_status", res.Status).
+			WithMeta("project_id", req.ProjectID).
+			WithMeta("raw_body", string(raw)).
+			WithFix("Ensure you have resourcemanager.projects.create on the parent folder/organization (roles/resourcemanager.projectCreator).").
+			WithFix("Check the project quota of your account.")
+	}
+
+	var op crmOperation
+	if err := json.Unmarshal(raw, &op); err != nil {
+		return apperr.New(ErrProjectCreate).WithCause(err).
+			WithMeta("raw_body", string(raw))
+	}
+	if op.Done || op.Name == "" {
+		return opError(op)
+	}
+	return waitOperation(ctx, accessToken, op.Name)
+}
+
+func waitOperation(ctx context.Context, accessToken, opName string) error {
+	u := "https://cloudresourcemanager.googleapis.com/v3/" + opName
+
+	client := &http.Client{Timeout: 20 * time.Second}
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return apperr.New(ErrProjectOp).WithCause(ctx.Err()).
+				WithMeta("op", opName).
+				WithFix("Project creation may still complete; check: advncd gcp project list")
+		case <-ticker.C:
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+			if err != nil {
+				return apperr.New(ErrProjectOp).WithCause(err)
+			}
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+
+			res, err := client.Do(req)
+			if err != nil {
+				return apperr.New(ErrProjectOp).WithCause(err)
+			}
+			raw, _ := io.ReadAll(res.Body)
+			_ = res.Body.Close()
+
+			if res.StatusCode < 200 || res.StatusCode >= 300 {
+				return apperr.New(ErrProjectOp).
+					WithCause(apperr.HTTP(res.StatusCode, raw)).
+					WithMeta("http_status", res.Status).
+					WithMeta("raw_body", string(raw)).
+					WithMeta("op", opName)
+			}
+
+			var op crmOperation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return apperr.New(ErrProjectOp).WithCause(err).
+					WithMeta("raw_body", string(raw))
+			}
+			if op.Done {
+				return opError(op)
+			}
+		}
+	}
+}
+
+func opError(op crmOperation) error {
+	if op.Error == nil {
+		return nil
+	}
+	return apperr.New(ErrProjectCreate).
+		WithMeta("op", op.Name).
+		WithMeta("error_code", fmt.Sprintf("%d", op.Error.Code)).
+		WithMeta("error_message", op.Error.Message)
+}
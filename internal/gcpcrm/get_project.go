@@ -11,7 +11,8 @@ import (
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
 )
 
-var ErrProjectGet = apperr.E("B-CRM-002", "Failed to fetch GCP project info")
+var ErrProjectGet = apperr.E("B-CRM-002", "Failed to fetch GCP project info",
+	apperr.WithSummary("The project does not exist, is mistyped, or your account can't access it."))
 
 type ProjectGet struct {
 	ProjectNumber string `json:"projectNumber"`
@@ -41,6 +42,7 @@ func GetProject(ctx context.Context, accessToken, projectID string) (*ProjectGet
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		return nil, apperr.New(ErrProjectGet).
+			WithCause(apperr.HTTP(res.StatusCode, body)).
 			WithMeta("http_status", res.Status).
 			WithMeta("raw_body", string(body)).
 			WithFix("Ensure you have access to this project.")
@@ -0,0 +1,121 @@
+package gcpcrm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var ErrTestPermissions = apperr.E("B-CRM-007", "Failed to test IAM permissions on project",
+	apperr.WithSummary("Resource Manager could not test which permissions you hold."))
+
+// TestIamPermissions returns which of perms the caller holds on the project.
+func TestIamPermissions(ctx context.Context, accessToken, projectID string, perms []string) ([]string, error) {
+	u := "https://cloudresourcemanager.googleapis.com/v1/projects/" + projectID + ":testIamPermissions"
+
+	b, _ := json.Marshal(map[string][]string{"permissions": perms})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, apperr.New(ErrTestPermissions).WithCause(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, apperr.New(ErrTestPermissions).WithCause(err).
+			WithFix("Check your internet connection and try again.")
+	}
+	defer res.Body.Close()
+
+	raw, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, apperr.New(ErrTestPermissions).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
+			WithMeta("http_status", res.Status).
+			WithMeta("raw_body", string(raw))
+	}
+
+	var out struct {
+		Permissions []string `json:"permissions"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, apperr.New(ErrTestPermissions).WithCause(err).
+			WithMeta("raw_body", string(raw))
+	}
+	return out.Permissions, nil
+}
+
+var ErrGetIamPolicy = apperr.E("B-CRM-008", "Failed to read project IAM policy",
+	apperr.WithSummary("The project's IAM policy could not be read."))
+
+type Binding struct {
+	Role    string   `json:"role"`
+	Members []string `json:"members"`
+}
+
+type Policy struct {
+	Bindings []Binding `json:"bindings"`
+}
+
+// RolesOf returns the roles granted directly to member (e.g. "serviceAccount:x@y").
+func (p *Policy) RolesOf(member string) []string {
+	var out []string
+	for _, b := range p.Bindings {
+		for _, m := range b.Members {
+			if m == member {
+				out = append(out, b.Role)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// GetIamPolicy returns the project's IAM policy.
+func GetIamPolicy(ctx context.Context, accessToken, projectID string) (*Policy, error) {
+	u := "https://cloudresourcemanager.googleapis.com/v1/projects/" + projectID + ":getIamPolicy"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader([]byte("{}")))
+	if err != nil {
+		return nil, apperr.New(ErrGetIamPolicy).WithCause(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, apperr.New(ErrGetIamPolicy).WithCause(err).
+			WithFix("Check your internet connection and try again.")
+	}
+	defer res.Body.Close()
+
+	raw, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		ae := apperr.New(ErrGetIamPolicy).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
+			WithMeta("http_status", res.Status).
+			WithMeta("raw_body", string(raw))
+		if res.StatusCode == http.StatusForbidden {
+			ae = ae.WithFix("Reading the policy needs resourcemanager.projects.getIamPolicy (e.g. roles/iam.securityReviewer).")
+		}
+		return nil, ae
+	}
+
+	var p Policy
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, apperr.New(ErrGetIamPolicy).WithCause(err).
+			WithMeta("raw_body", string(raw))
+	}
+	return &p, nil
+}
@@ -12,7 +12,8 @@ import (
 )
 
 var (
-	ErrProjectsList = apperr.E("B-CRM-001", "Failed to list GCP projects")
+	ErrProjectsList = apperr.E("B-CRM-001", "Failed to list GCP projects",
+		apperr.WithSummary("Resource Manager did not return the project list."))
 )
 
 type Project struct {
@@ -58,6 +59,7 @@ func ListProjects(ctx context.Context, accessToken string) ([]Project, error) {
 
 		if res.StatusCode < 200 || res.StatusCode >= 300 {
 			return nil, apperr.New(ErrProjectsList).
+				WithCause(apperr.HTTP(res.StatusCode, body)).
 				WithMeta("http_status", res.Status).
 				WithMeta("raw_body", string(body)).
 				WithFix("Ensure you are logged in: advncd login").
@@ -0,0 +1,135 @@
+package gcpcrm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var (
+	ErrProjectsSearch = apperr.E("B-CRM-003", "Failed to search GCP projects",
+		apperr.WithSummary("Resource Manager project search failed."))
+)
+
+// ProjectEntry is a project as returned by Cloud Resource Manager v3.
+type ProjectEntry struct {
+	ProjectID   string `json:"projectId"`
+	DisplayName string `json:"displayName"`
+	Parent      string `json:"parent"` // folders/123 or organizations/456 (empty for no-org projects)
+	State       string `json:"state"`
+
+	// Path is the human-readable ancestry ("example.com / team / prod"),
+	// filled in by ResolvePaths.
+	Path string `json:"path,omitempty"`
+}
+
+type searchResp struct {
+	Projects      []ProjectEntry `json:"projects"`
+	NextPageToken string         `json:"nextPageToken"`
+}
+
+// SearchQuery turns user input into a projects:search query. Plain text
+// matches project ID or display name prefixes; input containing ':' is
+// passed through as a raw query (e.g. "parent:folders/123", "labels.env:prod").
+func SearchQuery(text string) []string {
+	text = strings.TrimSpace(text)
+	switch {
+	case text == "":
+		return []string{"state:ACTIVE"}
+	case strings.Contains(text, ":"):
+		return []string{"state:ACTIVE " + text}
+	default:
+		// No OR in the query language: run both prefix searches and merge.
+		return []string{
+			"state:ACTIVE id:" + text + "*",
+			"state:ACTIVE displayName:" + text + "*",
+		}
+	}
+}
+
+// SearchProjects runs the queries (see SearchQuery) and returns up to max
+// unique ACTIVE projects in server order. more is true when results were cut.
+func SearchProjects(ctx context.Context, accessToken string, queries []string, max int) (projects []ProjectEntry, more bool, err error) {
+	seen := map[string]bool{}
+	for _, q := range queries {
+		got, m, err := search(ctx, accessToken, q, max)
+		if err != nil {
+			return nil, false, err
+		}
+		more = more || m
+		for _, p := range got {
+			if seen[p.ProjectID] || p.State != "ACTIVE" {
+				continue
+			}
+			seen[p.ProjectID] = true
+			projects = append(projects, p)
+		}
+	}
+	if max > 0 && len(projects) > max {
+		projects, more = projects[:max], true
+	}
+	return projects, more, nil
+}
+
+func search(ctx context.Context, accessToken, query string, max int) ([]ProjectEntry, bool, error) {
+	var all []ProjectEntry
+	pageToken := ""
+
+	client := &http.Client{Timeout: 20 * time.Second}
+
+	for {
+		u, _ := url.Parse("https://cloudresourcemanager.googleapis.com/v3/projects:search")
+		q := u.Query()
+		q.Set("query", query)
+		q.Set("pageSize", "100")
+		if pageToken != "" {
+			q.Set("pageToken", pageToken)
+		}
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, false, apperr.New(ErrProjectsSearch).WithCause(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, false, apperr.New(ErrProjectsSearch).WithCause(err).
+				WithFix("Check your internet connection and try again.")
+		}
+		body, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return nil, false, apperr.New(ErrProjectsSearch).
+				WithCause(apperr.HTTP(res.StatusCode, body)).
+				WithMeta("http_status", res.Status).
+				WithMeta("query", query).
+				WithMeta("raw_body", string(body)).
+				WithFix("Ensure you are logged in: advncd login").
+				WithFix("Check the filter syntax, e.g. --filter my-app or --filter parent:folders/123")
+		}
+
+		var out searchResp
+		if err := json.Unmarshal(body, &out); err != nil {
+			return nil, false, apperr.New(ErrProjectsSearch).WithCause(err).
+				WithMeta("raw_body", string(body))
+		}
+		all = append(all, out.Projects...)
+
+		if out.NextPageToken == "" {
+			return all, false, nil
+		}
+		if max > 0 && len(all) >= max {
+			return all, true, nil
+		}
+		pageToken = out.NextPageToken
+	}
+}
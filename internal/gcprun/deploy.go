@@ -8,14 +8,17 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
 )
 
 var (
-	ErrRunGet    = apperr.E("C-RUN-001", "Failed to fetch Cloud Run service")
-	ErrRunDeploy = apperr.E("C-RUN-002", "Failed to deploy Cloud Run service")
+	ErrRunGet = apperr.E("C-RUN-001", "Failed to fetch Cloud Run service",
+		apperr.WithSummary("The Cloud Run service could not be read."))
+	ErrRunDeploy = apperr.E("C-RUN-002", "Failed to deploy Cloud Run service",
+		apperr.WithSummary("Cloud Run rejected the service create or update."))
 )
 
 type DeployRequest struct {
@@ -27,13 +30,16 @@ type DeployRequest struct {
 }
 
 type DeployResult struct {
-	URL string
+	URL      string
+	Revision string // latest ready revision, short name
 }
 
 // Cloud Run v2 service representation (minimal)
 type service struct {
 	Name string `json:"name,omitempty"`
 	URI  string `json:"uri,omitempty"`
+	// Output only; cleared before patching.
+	LatestReadyRevision string `json:"latestReadyRevision,omitempty"`
 	Template struct {
 		Containers []struct {
 			Image string `json:"image"`
@@ -69,7 +75,7 @@ func DeployService(ctx context.Context, req DeployRequest) (*DeployResult, error
 		if err != nil {
 			return nil, err
 		}
-		return &DeployResult{URL: svc.URI}, nil
+		return deployResult(svc), nil
 	}
 
 	// update existing
@@ -87,6 +93,7 @@ func DeployService(ctx context.Context, req DeployRequest) (*DeployResult, error
 		},
 	}
 
+	current.LatestReadyRevision = ""
 	opName, err := patchService(ctx, req, current)
 	if err != nil {
 		return nil, err
@@ -101,7 +108,15 @@ func DeployService(ctx context.Context, req DeployRequest) (*DeployResult, error
 		return nil, err
 	}
 
-	return &DeployResult{URL: svc.URI}, nil
+	return deployResult(svc), nil
+}
+
+func deployResult(svc *service) *DeployResult {
+	rev := svc.LatestReadyRevision
+	if i := strings.LastIndex(rev, "/"); i >= 0 {
+		rev = rev[i+1:]
+	}
+	return &DeployResult{URL: svc.URI, Revision: rev}
 }
 
 func serviceURL(req DeployRequest) string {
@@ -131,6 +146,7 @@ func getService(ctx context.Context, req DeployRequest) (bool, *service, error)
 	}
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		return false, nil, apperr.New(ErrRunGet).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
 			WithMeta("http_status", res.Status).
 			WithMeta("raw_body", string(raw))
 	}
@@ -196,6 +212,7 @@ func createService(ctx context.Context, req DeployRequest) (string, error) {
 	raw, _ := io.ReadAll(res.Body)
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		return "", apperr.New(ErrRunDeploy).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
 			WithMeta("http_status", res.Status).
 			WithMeta("raw_body", string(raw)).
 			WithFix("Ensure Cloud Run API is enabled and you have permission to deploy.")
@@ -236,6 +253,7 @@ func patchService(ctx context.Context, req DeployRequest, current *service) (str
 	raw, _ := io.ReadAll(res.Body)
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		return "", apperr.New(ErrRunDeploy).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
 			WithMeta("http_status", res.Status).
 			WithMeta("raw_body", string(raw)).
 			WithFix("Ensure Cloud Run API is enabled and you have permission to deploy.")
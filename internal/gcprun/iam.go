@@ -12,7 +12,8 @@ import (
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
 )
 
-var ErrRunIAM = apperr.E("C-RUN-004", "Failed to configure Cloud Run IAM")
+var ErrRunIAM = apperr.E("C-RUN-004", "Failed to configure Cloud Run IAM",
+	apperr.WithSummary("The Cloud Run service's IAM policy could not be updated."))
 
 type iamPolicy struct {
 	Version  int `json:"version,omitempty"`
@@ -44,6 +45,7 @@ func AllowUnauthenticated(ctx context.Context, accessToken, projectID, region, s
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		return apperr.New(ErrRunIAM).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
 			WithMeta("http_status", res.Status).
 			WithMeta("raw_body", string(raw)).
 			WithFix("Ensure you have permission to set IAM policy on Cloud Run service.")
@@ -107,6 +109,7 @@ func AllowUnauthenticated(ctx context.Context, accessToken, projectID, region, s
 
 	if res2.StatusCode < 200 || res2.StatusCode >= 300 {
 		return apperr.New(ErrRunIAM).
+			WithCause(apperr.HTTP(res2.StatusCode, raw2)).
 			WithMeta("http_status", res2.Status).
 			WithMeta("raw_body", string(raw2)).
 			WithFix("If your org forbids public access, use authenticated access instead.")
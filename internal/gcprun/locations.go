@@ -0,0 +1,80 @@
+package gcprun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var ErrRunLocations = apperr.E("C-RUN-005", "Failed to list Cloud Run locations",
+	apperr.WithSummary("Cloud Run did not return its locations."))
+
+type Location struct {
+	LocationID  string `json:"locationId"`
+	DisplayName string `json:"displayName"`
+}
+
+type locationsResp struct {
+	Locations     []Location `json:"locations"`
+	NextPageToken string     `json:"nextPageToken"`
+}
+
+// ListLocations returns the regions where Cloud Run is available for the project.
+// Cloud Run v2 has no locations endpoint; v1 does and returns the same set.
+func ListLocations(ctx context.Context, accessToken, projectID string) ([]Location, error) {
+	var all []Location
+	pageToken := ""
+
+	client := &http.Client{Timeout: 20 * time.Second}
+
+	for {
+		u, _ := url.Parse(fmt.Sprintf("https://run.googleapis.com/v1/projects/%s/locations", projectID))
+		q := u.Query()
+		if pageToken != "" {
+			q.Set("pageToken", pageToken)
+		}
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, apperr.New(ErrRunLocations).WithCause(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, apperr.New(ErrRunLocations).WithCause(err).
+				WithFix("Check your internet connection and try again.")
+		}
+		raw, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return nil, apperr.New(ErrRunLocations).
+				WithCause(apperr.HTTP(res.StatusCode, raw)).
+				WithMeta("http_status", res.Status).
+				WithMeta("raw_body", string(raw)).
+				WithFix("Ensure Cloud Run API is enabled for this project.")
+		}
+
+		var out locationsResp
+		if err := json.Unmarshal(raw, &out); err != nil {
+			return nil, apperr.New(ErrRunLocations).WithCause(err).
+				WithMeta("raw_body", string(raw))
+		}
+		all = append(all, out.Locations...)
+
+		if out.NextPageToken == "" {
+			break
+		}
+		pageToken = out.NextPageToken
+	}
+
+	return all, nil
+}
@@ -11,7 +11,8 @@ import (
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
 )
 
-var ErrRunOp = apperr.E("C-RUN-003", "Failed to wait for Cloud Run operation")
+var ErrRunOp = apperr.E("C-RUN-003", "Failed to wait for Cloud Run operation",
+	apperr.WithSummary("The Cloud Run operation failed or did not finish."))
 
 type operation struct {
 	Name string `json:"name"`
@@ -53,6 +54,7 @@ func waitOperation(ctx context.Context, accessToken, opName string) error {
 
 			if res.StatusCode < 200 || res.StatusCode >= 300 {
 				return apperr.New(ErrRunOp).
+					WithCause(apperr.HTTP(res.StatusCode, raw)).
 					WithMeta("http_status", res.Status).
 					WithMeta("raw_body", string(raw)).
 					WithMeta("op", opName)
@@ -0,0 +1,88 @@
+package gcprun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var ErrRunList = apperr.E("C-RUN-006", "Failed to list Cloud Run services",
+	apperr.WithSummary("Cloud Run did not return the services in this region."))
+
+// Service is a deployed Cloud Run service, as listed.
+type Service struct {
+	Name       string `json:"name"` // short name, e.g. "my-app"
+	URI        string `json:"uri"`
+	UpdateTime string `json:"updateTime"`
+}
+
+type servicesResp struct {
+	Services []struct {
+		Name       string `json:"name"` // projects/{p}/locations/{r}/services/{name}
+		URI        string `json:"uri"`
+		UpdateTime string `json:"updateTime"`
+	} `json:"services"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// ListServices returns the Cloud Run services in projectID/region.
+func ListServices(ctx context.Context, accessToken, projectID, region string) ([]Service, error) {
+	var all []Service
+	pageToken := ""
+
+	client := &http.Client{Timeout: 20 * time.Second}
+
+	for {
+		u, _ := url.Parse(fmt.Sprintf("https://run.googleapis.com/v2/projects/%s/locations/%s/services", projectID, region))
+		q := u.Query()
+		if pageToken != "" {
+			q.Set("pageToken", pageToken)
+		}
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, apperr.New(ErrRunList).WithCause(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, apperr.New(ErrRunList).WithCause(err).
+				WithFix("Check your internet connection and try again.")
+		}
+		raw, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return nil, apperr.New(ErrRunList).
+				WithCause(apperr.HTTP(res.StatusCode, raw)).
+				WithMeta("http_status", res.Status).
+				WithMeta("raw_body", string(raw)).
+				WithFix("Ensure Cloud Run API is enabled for this project.")
+		}
+
+		var out servicesResp
+		if err := json.Unmarshal(raw, &out); err != nil {
+			return nil, apperr.New(ErrRunList).WithCause(err).
+				WithMeta("raw_body", string(raw))
+		}
+		for _, s := range out.Services {
+			all = append(all, Service{Name: path.Base(s.Name), URI: s.URI, UpdateTime: s.UpdateTime})
+		}
+
+		if out.NextPageToken == "" {
+			break
+		}
+		pageToken = out.NextPageToken
+	}
+
+	return all, nil
+}
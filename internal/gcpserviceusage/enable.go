@@ -0,0 +1,180 @@
+package gcpserviceusage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var (
+	ErrServiceEnable = apperr.E("B-SU-002", "Failed to enable APIs",
+		apperr.WithSummary("Service Usage rejected the request to enable APIs."),
+		apperr.WithDocs("GCP Console → APIs & Services → Library"))
+	ErrServiceOp = apperr.E("B-SU-003", "Failed to wait for API enablement",
+		apperr.WithSummary("The API enablement operation failed or did not finish."))
+	ErrServicesDisabled = apperr.E("B-SU-004", "Required Google APIs are not enabled",
+		apperr.WithSummary("Some services publish depends on are disabled in this project."),
+		apperr.WithDocs("GCP Console → APIs & Services → Library"))
+)
+
+// batchEnable accepts at most 20 services per call.
+const batchEnableMax = 20
+
+type suOperation struct {
+	Name  string `json:"name"`
+	Done  bool   `json:"done"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// ServiceName expands short names ("run") to "run.googleapis.com".
+func ServiceName(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.Contains(s, ".") {
+		return s
+	}
+	return s + ".googleapis.com"
+}
+
+// DisabledError lists the missing services with the ways to enable them.
+func DisabledError(missing []string) *apperr.Error {
+	return apperr.New(ErrServicesDisabled).
+		WithMeta("missing", strings.Join(missing, ", ")).
+		WithFix("Run: advncd apis enable").
+		WithFix("Or enable them in GCP Console → APIs & Services → Library")
+}
+
+// BatchEnable enables services (e.g. "run.googleapis.com") and waits until
+// Google reports them enabled. projectNumber may also be a project ID.
+// onWait, if set, is called on every poll with the time spent so far.
+func BatchEnable(ctx context.Context, accessToken, projectNumber string, services []string, onWait func(elapsed time.Duration)) error {
+	for len(services) > 0 {
+		n := len(services)
+		if n > batchEnableMax {
+			n = batchEnableMax
+		}
+		opName, err := startBatchEnable(ctx, accessToken, projectNumber, services[:n])
+		if err != nil {
+			return err
+		}
+		if opName != "" {
+			if err := waitOperation(ctx, accessToken, opName, onWait); err != nil {
+				return err
+			}
+		}
+		services = services[n:]
+	}
+	return nil
+}
+
+func startBatchEnable(ctx context.Context, accessToken, projectNumber string, services []string) (string, error) {
+	u := fmt.Sprintf("https://serviceusage.googleapis.com/v1/projects/%s/services:batchEnable", projectNumber)
+
+	b, _ := json.Marshal(map[string][]string{"serviceIds": services})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return "", apperr.New(ErrServiceEnable).WithCause(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return "", apperr.New(ErrServiceEnable).WithCause(err).
+			WithFix("Check your internet connection and try again.")
+	}
+	defer res.Body.Close()
+
+	raw, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return "", apperr.New(ErrServiceEnable).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
+			WithMeta("http_status", res.Status).
+			WithMeta("raw_body", string(raw)).
+			WithFix("Enabling APIs needs serviceusage.services.enable (roles/serviceusage.serviceUsageAdmin or owner).").
+			WithFix("Most APIs also require billing to be enabled for the project.")
+	}
+
+	var op suOperation
+	if err := json.Unmarshal(raw, &op); err != nil {
+		return "", apperr.New(ErrServiceEnable).WithCause(err).
+			WithMeta("raw_body", string(raw))
+	}
+	if op.Done {
+		return "", opError(op)
+	}
+	return op.Name, nil
+}
+
+func waitOperation(ctx context.Context, accessToken, opName string, onWait func(time.Duration)) error {
+	u := "https://serviceusage.googleapis.com/v1/" + opName
+	start := time.Now()
+
+	client := &http.Client{Timeout: 20 * time.Second}
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return apperr.New(ErrServiceOp).WithCause(ctx.Err()).
+				WithMeta("op", opName).
+				WithFix("Enablement may still complete in the background; re-check with: advncd status")
+		case <-ticker.C:
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+			if err != nil {
+				return apperr.New(ErrServiceOp).WithCause(err)
+			}
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+
+			res, err := client.Do(req)
+			if err != nil {
+				return apperr.New(ErrServiceOp).WithCause(err)
+			}
+			raw, _ := io.ReadAll(res.Body)
+			_ = res.Body.Close()
+
+			if res.StatusCode < 200 || res.StatusCode >= 300 {
+				return apperr.New(ErrServiceOp).
+					WithCause(apperr.HTTP(res.StatusCode, raw)).
+					WithMeta("http_status", res.Status).
+					WithMeta("raw_body", string(raw)).
+					WithMeta("op", opName)
+			}
+
+			var op suOperation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return apperr.New(ErrServiceOp).WithCause(err).
+					WithMeta("raw_body", string(raw))
+			}
+			if op.Done {
+				return opError(op)
+			}
+			if onWait != nil {
+				onWait(time.Since(start))
+			}
+		}
+	}
+}
+
+func opError(op suOperation) error {
+	if op.Error == nil {
+		return nil
+	}
+	return apperr.New(ErrServiceEnable).
+		WithMeta("op", op.Name).
+		WithMeta("error_code", fmt.Sprintf("%d", op.Error.Code)).
+		WithMeta("error_message", op.Error.Message)
+}
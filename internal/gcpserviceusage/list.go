@@ -0,0 +1,90 @@
+package gcpserviceusage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var ErrServiceList = apperr.E("B-SU-005", "Failed to list enabled APIs",
+	apperr.WithSeverity(apperr.SeverityWarn),
+	apperr.WithTitle("Could not verify enabled APIs"),
+	apperr.WithSummary("Service Usage did not return the list of enabled APIs."))
+
+type listResp struct {
+	Services []struct {
+		Name   string `json:"name"` // projects/{number}/services/{service}
+		Config struct {
+			Name string `json:"name"` // run.googleapis.com
+		} `json:"config"`
+	} `json:"services"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// ListEnabledServices returns the names of all enabled services
+// ("run.googleapis.com", ...) in one paginated listing.
+func ListEnabledServices(ctx context.Context, accessToken, projectNumber string) ([]string, error) {
+	var all []string
+	pageToken := ""
+
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	for {
+		u, _ := url.Parse("https://serviceusage.googleapis.com/v1/projects/" + projectNumber + "/services")
+		q := u.Query()
+		q.Set("filter", "state:ENABLED")
+		q.Set("pageSize", "200")
+		if pageToken != "" {
+			q.Set("pageToken", pageToken)
+		}
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, apperr.New(ErrServiceList).WithCause(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, apperr.New(ErrServiceList).WithCause(err).
+				WithFix("Check your internet connection and try again.")
+		}
+		body, _ := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return nil, apperr.New(ErrServiceList).
+				WithCause(apperr.HTTP(res.StatusCode, body)).
+				WithMeta("http_status", res.Status).
+				WithMeta("raw_body", string(body)).
+				WithFix("Listing APIs needs serviceusage.services.list (roles/serviceusage.serviceUsageViewer).")
+		}
+
+		var out listResp
+		if err := json.Unmarshal(body, &out); err != nil {
+			return nil, apperr.New(ErrServiceList).WithCause(err).
+				WithMeta("raw_body", string(body))
+		}
+		for _, s := range out.Services {
+			name := s.Config.Name
+			if name == "" {
+				name = s.Name[strings.LastIndex(s.Name, "/")+1:]
+			}
+			all = append(all, name)
+		}
+
+		if out.NextPageToken == "" {
+			break
+		}
+		pageToken = out.NextPageToken
+	}
+
+	return all, nil
+}
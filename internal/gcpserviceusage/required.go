@@ -0,0 +1,140 @@
+package gcpserviceusage
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// API is a Google API advncd depends on.
+type API struct {
+	Name      string   // run.googleapis.com
+	Title     string   // Cloud Run
+	NeededFor []string // commands/steps that fail without it
+}
+
+// RequiredAPIs is the single list every command consults (status, publish,
+// apis enable, project create). Add new dependencies here.
+var RequiredAPIs = []API{
+	{"run.googleapis.com", "Cloud Run", []string{"publish (deploy)", "status"}},
+	{"cloudbuild.googleapis.com", "Cloud Build", []string{"publish (build)"}},
+	{"artifactregistry.googleapis.com", "Artifact Registry", []string{"publish (image push)"}},
+	{"monitoring.googleapis.com", "Cloud Monitoring", []string{"metrics"}},
+	{"storage.googleapis.com", "Cloud Storage", []string{"publish (source upload)"}},
+	{"logging.googleapis.com", "Cloud Logging", []string{"publish (build logs)"}},
+}
+
+// NeededBy reports whether cmd fails without a: one of NeededFor is cmd
+// or a step of it ("publish (build)").
+func (a API) NeededBy(cmd string) bool {
+	for _, n := range a.NeededFor {
+		if n == cmd || strings.HasPrefix(n, cmd+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredNames returns the service names of RequiredAPIs.
+func RequiredNames() []string {
+	out := make([]string, 0, len(RequiredAPIs))
+	for _, a := range RequiredAPIs {
+		out = append(out, a.Name)
+	}
+	return out
+}
+
+const (
+	StateEnabled  = "ENABLED"
+	StateDisabled = "DISABLED"
+	StateUnknown  = "UNKNOWN"
+)
+
+// APIState is the result of checking one required API.
+type APIState struct {
+	API
+	State string
+	Err   error // set when State is UNKNOWN
+}
+
+// Readiness is the result of CheckRequired.
+type Readiness struct {
+	APIs    []APIState // in RequiredAPIs order
+	Missing []string   // DISABLED
+	Unknown []string   // couldn't be checked
+}
+
+// MissingFor returns the disabled APIs cmd needs (see API.NeededBy).
+func (r *Readiness) MissingFor(cmd string) []string {
+	var out []string
+	for _, st := range r.APIs {
+		if st.State == StateDisabled && st.NeededBy(cmd) {
+			out = append(out, st.Name)
+		}
+	}
+	return out
+}
+
+// perCheckTimeout bounds each individual request so one slow call can't
+// eat the caller's whole deadline.
+const perCheckTimeout = 10 * time.Second
+
+// CheckRequired reports the state of every required API. It lists enabled
+// services in one call; if that listing is not allowed, it falls back to
+// per-API lookups run concurrently.
+func CheckRequired(ctx context.Context, accessToken, projectNumber string) *Readiness {
+	states := make([]APIState, len(RequiredAPIs))
+	for i, a := range RequiredAPIs {
+		states[i] = APIState{API: a, State: StateUnknown}
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, 2*perCheckTimeout)
+	enabled, err := ListEnabledServices(listCtx, accessToken, projectNumber)
+	cancel()
+
+	if err == nil {
+		set := map[string]bool{}
+		for _, s := range enabled {
+			set[s] = true
+		}
+		for i := range states {
+			if set[states[i].Name] {
+				states[i].State = StateEnabled
+			} else {
+				states[i].State = StateDisabled
+			}
+		}
+	} else {
+		var wg sync.WaitGroup
+		for i := range states {
+			wg.Add(1)
+			go func(st *APIState) {
+				defer wg.Done()
+				c, cancel := context.WithTimeout(ctx, perCheckTimeout)
+				defer cancel()
+				state, err := GetServiceState(c, accessToken, projectNumber, st.Name)
+				switch {
+				case err != nil:
+					st.Err = err
+				case state == StateEnabled:
+					st.State = StateEnabled
+				default:
+					st.State = StateDisabled
+				}
+			}(&states[i])
+		}
+		wg.Wait()
+	}
+
+	r := &Readiness{APIs: states}
+	for _, st := range states {
+		switch st.State {
+		case StateDisabled:
+			r.Missing = append(r.Missing, st.Name)
+		case StateUnknown:
+			r.Unknown = append(r.Unknown, st.Name)
+		}
+	}
+	return r
+}
@@ -0,0 +1,36 @@
+package gcpserviceusage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNeededBy(t *testing.T) {
+	api := API{Name: "run.googleapis.com", NeededFor: []string{"publish (deploy)", "status"}}
+	for cmd, want := range map[string]bool{"publish": true, "status": true, "pub": false, "metrics": false} {
+		if got := api.NeededBy(cmd); got != want {
+			t.Errorf("NeededBy(%q) = %v, want %v", cmd, got, want)
+		}
+	}
+}
+
+func TestMissingFor(t *testing.T) {
+	r := &Readiness{}
+	for _, a := range RequiredAPIs {
+		r.APIs = append(r.APIs, APIState{API: a, State: StateDisabled})
+	}
+	want := []string{
+		"run.googleapis.com",
+		"cloudbuild.googleapis.com",
+		"artifactregistry.googleapis.com",
+		"storage.googleapis.com",
+		"logging.googleapis.com",
+	}
+	if got := r.MissingFor("publish"); !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingFor(publish) = %v, want %v", got, want)
+	}
+	r.APIs[0].State = StateEnabled
+	if got := r.MissingFor("publish"); len(got) != len(want)-1 || got[0] != "cloudbuild.googleapis.com" {
+		t.Errorf("MissingFor(publish) with run enabled = %v", got)
+	}
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
 )
 
-var ErrServiceGet = apperr.E("B-SU-001", "Failed to check API status")
+var ErrServiceGet = apperr.E("B-SU-001", "Failed to check API status",
+	apperr.WithSeverity(apperr.SeverityWarn),
+		apperr.WithTitle("Could not verify enabled APIs"),
+		apperr.WithSummary("Service Usage could not report whether an API is enabled."))
 
 type ServiceGet struct {
 	Name  string `json:"name"`  // projects/{number}/services/{service}
@@ -39,6 +42,7 @@ func GetServiceState(ctx context.Context, accessToken, projectNumber, serviceNam
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		return "", apperr.New(ErrServiceGet).
+			WithCause(apperr.HTTP(res.StatusCode, body)).
 			WithMeta("http_status", res.Status).
 			WithMeta("service", serviceName).
 			WithMeta("raw_body", string(body)).
@@ -12,7 +12,8 @@ import (
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
 )
 
-var ErrBucketCreate = apperr.E("C-GCS-002", "Failed to create Cloud Storage bucket")
+var ErrBucketCreate = apperr.E("C-GCS-002", "Failed to create Cloud Storage bucket",
+	apperr.WithSummary("The Cloud Build source bucket could not be created."))
 
 type bucketCreateReq struct {
 	Name         string `json:"name"`
@@ -56,6 +57,7 @@ func CreateBucket(ctx context.Context, accessToken, projectID, bucketName, locat
 	}
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		return apperr.New(ErrBucketCreate).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
 			WithMeta("http_status", res.Status).
 			WithMeta("bucket", bucketName).
 			WithMeta("location", location).
@@ -0,0 +1,85 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var ErrObject = apperr.E("C-GCS-004", "Cloud Storage object request failed",
+	apperr.WithSummary("Cloud Storage could not check or delete an uploaded source archive."))
+
+func objectURL(bucket, object string) string {
+	return fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o/%s", bucket, url.PathEscape(object))
+}
+
+// ObjectExists reports whether bucket/object exists. A missing bucket is
+// reported as false, like a missing object.
+func ObjectExists(ctx context.Context, accessToken, bucket, object string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL(bucket, object)+"?fields=name", nil)
+	if err != nil {
+		return false, apperr.New(ErrObject).WithCause(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return false, apperr.New(ErrObject).WithCause(err).
+			WithFix("Check your internet connection and try again.")
+	}
+	defer res.Body.Close()
+
+	raw, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode == 404 {
+		return false, nil
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return false, apperr.New(ErrObject).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
+			WithMeta("http_status", res.Status).
+			WithMeta("bucket", bucket).
+			WithMeta("object", object).
+			WithMeta("raw_body", string(raw)).
+			WithFix("Ensure you have storage.objects.get on the Cloud Build bucket.")
+	}
+	return true, nil
+}
+
+// DeleteObject removes bucket/object; a missing one is not an error.
+func DeleteObject(ctx context.Context, accessToken, bucket, object string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, objectURL(bucket, object), nil)
+	if err != nil {
+		return apperr.New(ErrObject).WithCause(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return apperr.New(ErrObject).WithCause(err).
+			WithFix("Check your internet connection and try again.")
+	}
+	defer res.Body.Close()
+
+	raw, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode == 404 {
+		return nil
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return apperr.New(ErrObject).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
+			WithMeta("http_status", res.Status).
+			WithMeta("bucket", bucket).
+			WithMeta("object", object).
+			WithMeta("raw_body", string(raw))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var ErrBucketPermissions = apperr.E("C-GCS-003", "Failed to test Cloud Storage bucket permissions",
+	apperr.WithSummary("Cloud Storage could not test your permissions on the bucket."))
+
+// TestBucketPermissions returns which of perms the caller holds on the bucket.
+// A missing bucket is reported as exists=false without an error.
+func TestBucketPermissions(ctx context.Context, accessToken, bucket string, perms []string) (granted []string, exists bool, err error) {
+	u, _ := url.Parse(fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/iam/testPermissions", bucket))
+	q := u.Query()
+	for _, p := range perms {
+		q.Add("permissions", p)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, false, apperr.New(ErrBucketPermissions).WithCause(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, false, apperr.New(ErrBucketPermissions).WithCause(err).
+			WithFix("Check your internet connection and try again.")
+	}
+	defer res.Body.Close()
+
+	raw, _ := io.ReadAll(res.Body)
+
+	if res.StatusCode == 404 {
+		return nil, false, nil
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, true, apperr.New(ErrBucketPermissions).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
+			WithMeta("http_status", res.Status).
+			WithMeta("bucket", bucket).
+			WithMeta("raw_body", string(raw))
+	}
+
+	var out struct {
+		Permissions []string `json:"permissions"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, true, apperr.New(ErrBucketPermissions).WithCause(err).
+			WithMeta("raw_body", string(raw))
+	}
+	return out.Permissions, true, nil
+}
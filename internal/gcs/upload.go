@@ -1,46 +1,71 @@
 package gcs
 
 import (
+	"bufio"
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/debug"
 )
 
-var ErrUpload = apperr.E("C-GCS-001", "Failed to upload source to Cloud Storage")
+var ErrUpload = apperr.E("C-GCS-001", "Failed to upload source to Cloud Storage",
+	apperr.WithSummary("The source archive could not be uploaded to Cloud Storage."))
 
-type uploadResp struct {
-	Bucket string `json:"bucket"`
-	Name   string `json:"name"`
-	Size   string `json:"size"`
+// ChunkSize is how much of a resumable upload is sent per request and
+// held in memory for a retry. GCS wants a multiple of 256 KiB.
+const ChunkSize = 8 << 20
+
+// chunkAttempts bounds retries of one chunk after transient failures.
+const chunkAttempts = 6
+
+// retryBackoff is the first pause before retrying a chunk; it doubles up
+// to 30s.
+var retryBackoff = time.Second
+
+// Upload is a GCS resumable upload session.
+type Upload struct {
+	URL         string // session URI; valid for a week
+	accessToken string
+	bucket      string
+	object      string
+	client      *http.Client
+
+	// OnProgress, if set, gets the number of bytes GCS has stored after
+	// every chunk.
+	OnProgress func(stored int64)
 }
 
-// UploadObjectMedia uploads raw bytes to GCS.
-// Returns httpStatus (0 on success).
-func UploadObjectMedia(ctx context.Context, accessToken, bucket, objectName string, content []byte) (int, error) {
+// StartUpload opens a resumable upload session for bucket/object.
+// Returns httpStatus (0 on success) so callers can create a missing
+// bucket on 404.
+func StartUpload(ctx context.Context, accessToken, bucket, objectName, contentType string) (*Upload, int, error) {
 	u, _ := url.Parse(fmt.Sprintf("https://storage.googleapis.com/upload/storage/v1/b/%s/o", bucket))
 	q := u.Query()
-	q.Set("uploadType", "media")
+	q.Set("uploadType", "resumable")
 	q.Set("name", objectName)
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(content))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader("{}"))
 	if err != nil {
-		return 0, apperr.New(ErrUpload).WithCause(err)
+		return nil, 0, apperr.New(ErrUpload).WithCause(err)
 	}
 	req.Header.Set("Authorization", "Bearer "+accessToken)
-	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-Upload-Content-Type", contentType)
 
-	client := &http.Client{Timeout: 60 * time.Second}
+	client := &http.Client{Timeout: 30 * time.Second}
 	res, err := client.Do(req)
 	if err != nil {
-		return 0, apperr.New(ErrUpload).WithCause(err).
+		return nil, 0, apperr.New(ErrUpload).WithCause(err).
 			WithFix("Check your internet connection and try again.")
 	}
 	defer res.Body.Close()
@@ -48,16 +73,217 @@ func UploadObjectMedia(ctx context.Context, accessToken, bucket, objectName stri
 	raw, _ := io.ReadAll(res.Body)
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return res.StatusCode, apperr.New(ErrUpload).
+		return nil, res.StatusCode, apperr.New(ErrUpload).
+			WithCause(apperr.HTTP(res.StatusCode, raw)).
 			WithMeta("http_status", res.Status).
 			WithMeta("bucket", bucket).
 			WithMeta("object", objectName).
 			WithMeta("raw_body", string(raw)).
 			WithFix("Ensure Cloud Storage API is enabled and you have permission to write objects.")
 	}
+	loc := res.Header.Get("Location")
+	if loc == "" {
+		return nil, res.StatusCode, apperr.New(ErrUpload).
+			WithMeta("bucket", bucket).
+			WithMeta("object", objectName).
+			WithMeta("reason", "no session URI in the response")
+	}
+
+	return &Upload{
+		URL:         loc,
+		accessToken: accessToken,
+		bucket:      bucket,
+		object:      objectName,
+		// Per chunk: 8 MiB on a slow uplink takes a while.
+		client: &http.Client{Timeout: 5 * time.Minute},
+	}, 0, nil
+}
+
+// Send streams r to the session in ChunkSize pieces; the total size need
+// not be known. Only the current chunk is buffered: after a network error
+// or a 429/5xx the session is asked how much it stored and the rest of the
+// chunk is sent again, with backoff. Returns the object size.
+func (up *Upload) Send(ctx context.Context, r io.Reader) (int64, error) {
+	br := bufio.NewReaderSize(r, 64<<10)
+	buf := make([]byte, ChunkSize)
+	var offset int64 // bytes GCS has stored
+
+	for {
+		n, err := io.ReadFull(br, buf)
+		last := false
+		switch {
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			last = true
+		case err != nil:
+			return offset, err
+		default:
+			// A full chunk may still be the last one.
+			if _, err := br.Peek(1); errors.Is(err, io.EOF) {
+				last = true
+			} else if err != nil {
+				return offset, err
+			}
+		}
+
+		done, err := up.sendChunk(ctx, buf[:n], offset, last)
+		if err != nil {
+			return offset, err
+		}
+		offset += int64(n)
+		if up.OnProgress != nil {
+			up.OnProgress(offset)
+		}
+		if done || last {
+			return offset, nil
+		}
+	}
+}
+
+// sendChunk sends chunk, which starts at offset, until GCS has stored all
+// of it. done is true when GCS finalized the object.
+func (up *Upload) sendChunk(ctx context.Context, chunk []byte, offset int64, last bool) (done bool, err error) {
+	sent := 0 // bytes of chunk GCS has confirmed
+	backoff := retryBackoff
+
+	for attempt := 1; ; attempt++ {
+		status, stored, err := up.put(ctx, chunk[sent:], offset+int64(sent), offset+int64(len(chunk)), last)
+		switch {
+		case err == nil && (status == http.StatusOK || status == http.StatusCreated):
+			return true, nil
+		case err == nil && status == http.StatusPermanentRedirect:
+			// 308: GCS stored bytes [0, stored) and wants the rest.
+			if stored >= offset+int64(len(chunk)) && !last {
+				return false, nil
+			}
+			if stored >= offset && stored > offset+int64(sent) {
+				sent = int(stored - offset)
+				attempt = 0 // progress was made
+				continue
+			}
+		case err != nil && !transient(status, err):
+			if status == 0 {
+				err = apperr.New(ErrUpload).WithCause(err).
+					WithMeta("bucket", up.bucket).
+					WithMeta("object", up.object)
+			}
+			return false, err
+		}
+
+		if attempt >= chunkAttempts {
+			if err == nil {
+				err = fmt.Errorf("upload stalled at byte %d", offset+int64(sent))
+			}
+			return false, apperr.New(ErrUpload).WithCause(err).
+				WithMeta("bucket", up.bucket).
+				WithMeta("object", up.object).
+				WithMeta("offset", strconv.FormatInt(offset+int64(sent), 10)).
+				WithFix("Check your internet connection and run publish again.")
+		}
+		debug.Logf("gcs: chunk at %d failed (status %d, %v); retrying in %s", offset+int64(sent), status, err, backoff)
+		select {
+		case <-ctx.Done():
+			return false, apperr.New(ErrUpload).WithCause(ctx.Err())
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+
+		// Where did we get to? The failed request may have been stored in
+		// part or whole.
+		if stored, ok := up.query(ctx); ok && stored >= offset {
+			if s := int(stored - offset); s > sent && s <= len(chunk) {
+				sent = s
+			}
+		}
+	}
+}
+
+// put sends data as bytes [from, end) of the object; total is known only
+// for the last chunk. stored comes from the Range header of a 308.
+func (up *Upload) put(ctx context.Context, data []byte, from, end int64, last bool) (status int, stored int64, err error) {
+	total := "*"
+	if last {
+		total = strconv.FormatInt(end, 10)
+	}
+	contentRange := fmt.Sprintf("bytes %d-%d/%s", from, end-1, total)
+	if len(data) == 0 {
+		contentRange = "bytes */" + total
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, up.URL, bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, apperr.New(ErrUpload).WithCause(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+up.accessToken)
+	req.Header.Set("Content-Range", contentRange)
 
-	// optional parse (for debug)
-	var out uploadResp
-	_ = json.Unmarshal(raw, &out)
-	return 0, nil
-}
\ No newline at end of file
+	res, err := up.client.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer res.Body.Close()
+	raw, _ := io.ReadAll(res.Body)
+
+	switch {
+	case res.StatusCode == http.StatusPermanentRedirect:
+		return res.StatusCode, storedBytes(res.Header.Get("Range")), nil
+	case res.StatusCode == http.StatusOK || res.StatusCode == http.StatusCreated:
+		return res.StatusCode, end, nil
+	}
+	return res.StatusCode, 0, apperr.New(ErrUpload).
+		WithCause(apperr.HTTP(res.StatusCode, raw)).
+		WithMeta("http_status", res.Status).
+		WithMeta("bucket", up.bucket).
+		WithMeta("object", up.object).
+		WithMeta("raw_body", string(raw))
+}
+
+// query asks the session how many bytes it has stored.
+func (up *Upload) query(ctx context.Context) (int64, bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, up.URL, nil)
+	if err != nil {
+		return 0, false
+	}
+	req.Header.Set("Authorization", "Bearer "+up.accessToken)
+	req.Header.Set("Content-Range", "bytes */*")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, false
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+	if res.StatusCode != http.StatusPermanentRedirect {
+		return 0, false
+	}
+	return storedBytes(res.Header.Get("Range")), true
+}
+
+// storedBytes parses "bytes=0-N" into N+1; no header means nothing stored.
+func storedBytes(h string) int64 {
+	_, last, ok := strings.Cut(strings.TrimPrefix(h, "bytes="), "-")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n + 1
+}
+
+// transient reports whether a failed chunk is worth retrying: network
+// errors and the statuses GCS documents as retryable.
+func transient(status int, err error) bool {
+	switch status {
+	case 0:
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,289 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// session fakes a GCS resumable upload session. fault, if set, sees every
+// data request (numbered from 1) and may take it over.
+type session struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	stored  []byte
+	done    bool
+	puts    int      // data requests
+	ranges  []string // Content-Range of each data request
+	queries int
+
+	fault func(w http.ResponseWriter, r *http.Request, n int, from int64, s *session) bool
+}
+
+func (s *session) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut || r.Header.Get("Authorization") != "Bearer token" {
+		s.t.Errorf("unexpected request %s %v", r.Method, r.Header)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	cr := r.Header.Get("Content-Range")
+	if cr == "bytes */*" {
+		s.mu.Lock()
+		s.queries++
+		s.mu.Unlock()
+		s.status(w)
+		return
+	}
+
+	from, total, ok := parseRange(cr)
+	if !ok {
+		s.t.Errorf("bad Content-Range %q", cr)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	s.puts++
+	n := s.puts
+	s.ranges = append(s.ranges, cr)
+	s.mu.Unlock()
+
+	if s.fault != nil && s.fault(w, r, n, from, s) {
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.t.Errorf("read body: %v", err)
+		return
+	}
+	s.store(from, body)
+	s.mu.Lock()
+	if total >= 0 && int64(len(s.stored)) == total {
+		s.done = true
+	}
+	s.mu.Unlock()
+	s.status(w)
+}
+
+// store appends data written at from; GCS ignores bytes it already has
+// and rejects gaps.
+func (s *session) store(from int64, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	have := int64(len(s.stored))
+	if from > have {
+		s.t.Errorf("write at %d leaves a gap after %d", from, have)
+		return
+	}
+	if skip := have - from; skip < int64(len(data)) {
+		s.stored = append(s.stored, data[skip:]...)
+	}
+}
+
+func (s *session) status(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if len(s.stored) > 0 {
+		w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(s.stored)-1))
+	}
+	w.WriteHeader(http.StatusPermanentRedirect)
+}
+
+// parseRange reads "bytes a-b/total" (total -1 for "*").
+func parseRange(h string) (from, total int64, ok bool) {
+	spec, tot, ok := strings.Cut(strings.TrimPrefix(h, "bytes "), "/")
+	if !ok {
+		return 0, 0, false
+	}
+	total = -1
+	if tot != "*" {
+		if total, ok = atoi(tot); !ok {
+			return 0, 0, false
+		}
+	}
+	a, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	from, ok = atoi(a)
+	return from, total, ok
+}
+
+func atoi(s string) (int64, bool) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	return n, err == nil
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	rand.New(rand.NewSource(int64(n))).Read(b)
+	return b
+}
+
+func runUpload(t *testing.T, s *session, data []byte) (int64, []int64, error) {
+	t.Helper()
+	old := retryBackoff
+	retryBackoff = time.Millisecond
+	defer func() { retryBackoff = old }()
+
+	s.t = t
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+	up := &Upload{URL: srv.URL, accessToken: "token", bucket: "b", object: "o", client: srv.Client()}
+	var progress []int64
+	up.OnProgress = func(n int64) { progress = append(progress, n) }
+	n, err := up.Send(context.Background(), bytes.NewReader(data))
+	return n, progress, err
+}
+
+func TestSend(t *testing.T) {
+	const mib = 1 << 20
+	cases := []struct {
+		name     string
+		size     int
+		fault    func(w http.ResponseWriter, r *http.Request, n int, from int64, s *session) bool
+		ranges   []string
+		progress []int64
+	}{
+		{
+			name:     "one short chunk",
+			size:     3 * mib,
+			ranges:   []string{"bytes 0-3145727/3145728"},
+			progress: []int64{3 * mib},
+		},
+		{
+			name:     "size is a multiple of the chunk",
+			size:     2 * ChunkSize,
+			ranges:   []string{"bytes 0-8388607/*", "bytes 8388608-16777215/16777216"},
+			progress: []int64{ChunkSize, 2 * ChunkSize},
+		},
+		{
+			name: "503 after part of a chunk was stored",
+			size: ChunkSize + 2*mib,
+			fault: func(w http.ResponseWriter, r *http.Request, n int, from int64, s *session) bool {
+				if n != 1 {
+					return false
+				}
+				body, _ := io.ReadAll(r.Body)
+				s.store(from, body[:3*mib])
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return true
+			},
+			ranges: []string{
+				"bytes 0-8388607/*",
+				"bytes 3145728-8388607/*", // resumed where the query said
+				"bytes 8388608-10485759/10485760",
+			},
+			progress: []int64{ChunkSize, ChunkSize + 2*mib},
+		},
+		{
+			name: "connection dropped in the middle of a chunk",
+			size: 2*ChunkSize + mib,
+			fault: func(w http.ResponseWriter, r *http.Request, n int, from int64, s *session) bool {
+				if n != 2 {
+					return false
+				}
+				part := make([]byte, 5*mib)
+				if _, err := io.ReadFull(r.Body, part); err != nil {
+					s.t.Errorf("read body: %v", err)
+				}
+				s.store(from, part)
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					s.t.Error(err)
+					return true
+				}
+				conn.Close()
+				return true
+			},
+			ranges: []string{
+				"bytes 0-8388607/*",
+				"bytes 8388608-16777215/*",
+				"bytes 13631488-16777215/*",
+				"bytes 16777216-17825791/17825792",
+			},
+			progress: []int64{ChunkSize, 2 * ChunkSize, 2*ChunkSize + mib},
+		},
+		{
+			name: "308 with a short Range resends the rest at once",
+			size: ChunkSize,
+			fault: func(w http.ResponseWriter, r *http.Request, n int, from int64, s *session) bool {
+				if n != 1 {
+					return false
+				}
+				body, _ := io.ReadAll(r.Body)
+				s.store(from, body[:mib])
+				s.status(w)
+				return true
+			},
+			ranges:   []string{"bytes 0-8388607/8388608", "bytes 1048576-8388607/8388608"},
+			progress: []int64{ChunkSize},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data := randomBytes(c.size)
+			s := &session{fault: c.fault}
+			n, progress, err := runUpload(t, s, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != int64(len(data)) {
+				t.Errorf("Send = %d, want %d", n, len(data))
+			}
+			if !s.done || !bytes.Equal(s.stored, data) {
+				t.Errorf("stored %d bytes (finalized %v), want the %d sent", len(s.stored), s.done, len(data))
+			}
+			if fmt.Sprint(s.ranges) != fmt.Sprint(c.ranges) {
+				t.Errorf("requests:\n  %s\nwant:\n  %s", strings.Join(s.ranges, "\n  "), strings.Join(c.ranges, "\n  "))
+			}
+			if fmt.Sprint(progress) != fmt.Sprint(c.progress) {
+				t.Errorf("progress = %v, want %v", progress, c.progress)
+			}
+		})
+	}
+}
+
+func TestSendFails(t *testing.T) {
+	t.Run("permanent error is not retried", func(t *testing.T) {
+		s := &session{fault: func(w http.ResponseWriter, r *http.Request, n int, from int64, s *session) bool {
+			w.WriteHeader(http.StatusForbidden)
+			return true
+		}}
+		_, _, err := runUpload(t, s, randomBytes(1024))
+		if !errors.Is(err, ErrUpload) {
+			t.Fatalf("err = %v, want %v", err, ErrUpload)
+		}
+		if s.puts != 1 || s.queries != 0 {
+			t.Errorf("%d requests and %d queries, want 1 and 0", s.puts, s.queries)
+		}
+	})
+	t.Run("retries are bounded", func(t *testing.T) {
+		s := &session{fault: func(w http.ResponseWriter, r *http.Request, n int, from int64, s *session) bool {
+			_, _ = io.Copy(io.Discard, r.Body)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return true
+		}}
+		_, _, err := runUpload(t, s, randomBytes(1024))
+		if !errors.Is(err, ErrUpload) {
+			t.Fatalf("err = %v, want %v", err, ErrUpload)
+		}
+		if s.puts != chunkAttempts {
+			t.Errorf("%d attempts, want %d", s.puts, chunkAttempts)
+		}
+	})
+}
@@ -0,0 +1,512 @@
+// Package gomod finds what a build of one Go package needs from a
+// repository: its module, the go.work in effect, and the local
+// directories those point to (replace targets, workspace modules).
+package gomod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var ErrLayout = apperr.E("C-PUBLISH-004", "Unable to read the Go module layout",
+	apperr.WithSummary("go.mod or go.work could not be read, or points to a directory publish can't use."))
+
+// ErrNoModule is returned by Resolve when no go.mod is found in the
+// directory or above it.
+var ErrNoModule = errors.New("no go.mod in the directory or any parent")
+
+// Layout is what gets packed to build one package.
+type Layout struct {
+	Root       string // build context: the directory archived, absolute
+	Module     string // directory of the main module
+	ModulePath string
+	GoVersion  string // go directive of the main module, "" if none
+	Package    string // directory of the package to build
+	Work       string // go.work in effect, "" if none
+	// Dirs are the module directories packed, relative to Root and
+	// slash-separated, sorted; nil means all of Root.
+	Dirs []string
+	// GoWork, if set, is the go.work placed at Root in the archive
+	// (replacing one there): it lists only the modules packed, with paths
+	// relative to Root. GoWorkSum goes with it.
+	GoWork    []byte
+	GoWorkSum []byte
+	// GoMod, if set, takes the place of the main module's go.mod at Root:
+	// the same file with local replaces pointing into Extra.
+	GoMod []byte
+	// Extra are needed module directories outside Root, packed under
+	// ModulesDir (see AtModule).
+	Extra []Extra
+
+	// What Resolve found, for AtModule.
+	r      *resolver
+	work   *modfile.WorkFile
+	needed []string
+}
+
+// Extra is a directory packed under another name.
+type Extra struct {
+	Dir  string // absolute
+	Name string // in the archive, slash-separated
+}
+
+// ModulesDir is where AtModule packs the modules found outside the main
+// module. The go command skips directories starting with a dot in
+// package patterns, so ./... at the root doesn't reach into them.
+const ModulesDir = ".advncd"
+
+// Buildable is the package to build, relative to Root ("./svc/cmd/api"),
+// or "" for Root itself, which is what buildpacks build by default.
+func (l *Layout) Buildable() string {
+	rel, err := filepath.Rel(l.Root, l.Package)
+	if err != nil || rel == "." {
+		return ""
+	}
+	return "./" + filepath.ToSlash(rel)
+}
+
+// Nearest returns the file name closest to Package, looking from Package
+// up to Root, relative to Root and slash-separated ("" if none).
+func (l *Layout) Nearest(name string) string {
+	for dir := l.Package; ; dir = filepath.Dir(dir) {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.Mode().IsRegular() {
+			rel, err := filepath.Rel(l.Root, filepath.Join(dir, name))
+			if err != nil {
+				return ""
+			}
+			return filepath.ToSlash(rel)
+		}
+		if dir == l.Root || dir == filepath.Dir(dir) {
+			return ""
+		}
+	}
+}
+
+// AtModule lays l out again with the main module at the root, for
+// builders that need a go.mod there (buildpacks). The needed modules
+// outside it are packed under ModulesDir, keeping their places relative
+// to each other, and the main go.mod and the generated go.work point
+// there instead of to the original directories.
+func (l *Layout) AtModule() (*Layout, error) {
+	if l.Root == l.Module {
+		return l, nil
+	}
+	m := &Layout{
+		Root:       l.Module,
+		Module:     l.Module,
+		ModulePath: l.ModulePath,
+		GoVersion:  l.GoVersion,
+		Package:    l.Package,
+		Work:       l.Work,
+		GoWorkSum:  l.GoWorkSum,
+	}
+	var outside []string
+	for _, d := range l.needed {
+		if !within(l.Module, d) {
+			outside = append(outside, d)
+		}
+	}
+	place := func(dir string) string {
+		if within(l.Module, dir) {
+			return relPath(l.Module, dir)
+		}
+		rel, _ := filepath.Rel(l.Root, dir)
+		return "./" + path.Join(ModulesDir, filepath.ToSlash(rel))
+	}
+	for _, d := range outside {
+		m.Extra = append(m.Extra, Extra{Dir: d, Name: strings.TrimPrefix(place(d), "./")})
+	}
+
+	var err error
+	if m.GoMod, err = l.r.goMod(l.Module, l.needed, place); err != nil {
+		return nil, apperr.New(ErrLayout).WithCause(err).
+			WithMeta("dir", l.Module)
+	}
+	if l.work != nil {
+		main, err := l.r.load(l.Module)
+		if err != nil {
+			return nil, err
+		}
+		if m.GoWork, err = l.r.goWork(place, l.Module, main, l.work, l.needed); err != nil {
+			return nil, apperr.New(ErrLayout).WithCause(err).
+				WithMeta("root", m.Root)
+		}
+	}
+	return m, nil
+}
+
+// Resolve lays out the build of the package in dir. A single module with
+// no local replaces and no go.work packs as before: Root is the module
+// and nothing is generated. Otherwise Root is the closest directory
+// holding every module needed, and a go.work is generated at Root so the
+// build there resolves them the way `go build` does in dir.
+//
+// GOWORK is honoured as by the go command: "off" ignores workspaces, a
+// path selects one, and by default the nearest go.work above dir is used.
+func Resolve(dir string) (*Layout, error) {
+	pkg, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(pkg)
+	if err != nil {
+		return nil, apperr.New(ErrLayout).WithCause(err).
+			WithMeta("dir", pkg)
+	}
+	if !info.IsDir() {
+		return nil, apperr.New(ErrLayout).
+			WithMeta("dir", pkg).
+			WithFix("Pass the directory of the main package, e.g. advncd publish ./cmd/api")
+	}
+
+	module := findUp(pkg, "go.mod")
+	if module == "" {
+		return nil, ErrNoModule
+	}
+	l := &Layout{Module: module, Package: pkg}
+
+	r := &resolver{modules: map[string]*modfile.File{}, byPath: map[string]string{}, replace: map[string]string{}}
+	main, err := r.load(module)
+	if err != nil {
+		return nil, err
+	}
+	if main.Module == nil || main.Module.Mod.Path == "" {
+		return nil, apperr.New(ErrLayout).
+			WithMeta("go_mod", filepath.Join(module, "go.mod")).
+			WithFix("Run: go mod init <module path>")
+	}
+	l.ModulePath = main.Module.Mod.Path
+	if main.Go != nil {
+		l.GoVersion = main.Go.Version
+	}
+
+	var work *modfile.WorkFile
+	if l.Work, err = workFile(pkg); err != nil {
+		return nil, err
+	}
+	if l.Work != "" {
+		if work, err = r.workspace(l.Work, module); err != nil {
+			return nil, err
+		}
+	} else {
+		r.replaces(module, main.Replace)
+	}
+
+	needed, err := r.needed(module)
+	if err != nil {
+		return nil, err
+	}
+	l.Root = commonDir(needed)
+	l.r, l.work, l.needed = r, work, needed
+	if work == nil && l.Root == module {
+		return l, nil
+	}
+
+	for _, d := range needed {
+		rel, _ := filepath.Rel(l.Root, d)
+		l.Dirs = append(l.Dirs, filepath.ToSlash(rel))
+	}
+	sort.Strings(l.Dirs)
+	if len(l.Dirs) == 1 && l.Dirs[0] == "." {
+		l.Dirs = nil
+	}
+	place := func(dir string) string { return relPath(l.Root, dir) }
+	if l.GoWork, err = r.goWork(place, module, main, work, needed); err != nil {
+		return nil, apperr.New(ErrLayout).WithCause(err).
+			WithMeta("root", l.Root)
+	}
+	if l.Work != "" {
+		sum, err := os.ReadFile(l.Work + ".sum")
+		if err != nil && !os.IsNotExist(err) {
+			return nil, apperr.New(ErrLayout).WithCause(err).
+				WithMeta("go_work", l.Work)
+		}
+		l.GoWorkSum = sum
+	}
+	return l, nil
+}
+
+// resolver collects modules by directory and where module paths lead.
+type resolver struct {
+	modules map[string]*modfile.File // module directory -> its go.mod
+	byPath  map[string]string        // workspace module path -> directory
+	replace map[string]string        // module path -> local replacement directory
+	uses    []string                 // workspace module directories, in go.work order
+	work    string                   // go.work directory
+}
+
+// load parses the go.mod in dir once.
+func (r *resolver) load(dir string) (*modfile.File, error) {
+	if f, ok := r.modules[dir]; ok {
+		return f, nil
+	}
+	f, err := parseMod(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, apperr.New(ErrLayout).WithCause(err).
+			WithMeta("dir", dir).
+			WithFix("Check that go.mod exists there and that `go build` works locally.")
+	}
+	r.modules[dir] = f
+	return f, nil
+}
+
+// workspace reads go.work at path. Every module listed is a main module,
+// so its replaces apply; go.work's own replaces win over theirs.
+func (r *resolver) workspace(path, module string) (*modfile.WorkFile, error) {
+	work, err := parseWork(path)
+	if err != nil {
+		return nil, apperr.New(ErrLayout).WithCause(err).
+			WithMeta("go_work", path)
+	}
+	r.work = filepath.Dir(path)
+	member := false
+	for _, u := range work.Use {
+		dir := resolvePath(r.work, u.Path)
+		f, err := r.load(dir)
+		if err != nil {
+			return nil, err
+		}
+		r.uses = append(r.uses, dir)
+		if f.Module != nil {
+			r.byPath[f.Module.Mod.Path] = dir
+		}
+		r.replaces(dir, f.Replace)
+		member = member || dir == module
+	}
+	if !member {
+		rel, _ := filepath.Rel(r.work, module)
+		return nil, apperr.New(ErrLayout).
+			WithCause(fmt.Errorf("module %s is not listed in %s", module, path)).
+			WithMeta("go_work", path).
+			WithFix("Add it to the workspace: go work use ./" + filepath.ToSlash(rel)).
+			WithFix("Or build without the workspace: GOWORK=off advncd publish")
+	}
+	r.replaces(r.work, work.Replace)
+	return work, nil
+}
+
+// replaces records the local replace directives of the file in dir.
+func (r *resolver) replaces(dir string, reps []*modfile.Replace) {
+	for _, rep := range reps {
+		if local(rep) {
+			r.replace[rep.Old.Path] = resolvePath(dir, rep.New.Path)
+		}
+	}
+}
+
+// local reports whether rep replaces a module with a directory.
+func local(rep *modfile.Replace) bool {
+	return rep.New.Version == "" && modfile.IsDirectoryPath(rep.New.Path)
+}
+
+// needed returns the module directories the build reads: module, and
+// every workspace module or local replacement required from a directory
+// already needed. Since go 1.17 go.mod lists indirect requirements too,
+// so following requires finds them all.
+func (r *resolver) needed(module string) ([]string, error) {
+	seen := map[string]bool{module: true}
+	queue := []string{module}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		f, err := r.load(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, req := range f.Require {
+			next, ok := r.replace[req.Mod.Path]
+			if !ok {
+				next, ok = r.byPath[req.Mod.Path]
+			}
+			if ok && !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for d := range seen {
+		out = append(out, d)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// goWork generates the go.work for the archive: the workspace modules
+// needed (or the main module alone without a workspace) and the replaces
+// of the original go.work, with directories where place puts them.
+func (r *resolver) goWork(place func(dir string) string, module string, main *modfile.File, work *modfile.WorkFile, needed []string) ([]byte, error) {
+	var goVersion, toolchain string
+	var reps []*modfile.Replace
+	uses := []string{module}
+	if work != nil {
+		if work.Go != nil {
+			goVersion = work.Go.Version
+		}
+		if work.Toolchain != nil {
+			toolchain = work.Toolchain.Name
+		}
+		reps = work.Replace
+		uses = nil
+		for _, u := range r.uses {
+			if contains(needed, u) {
+				uses = append(uses, u)
+			}
+		}
+	} else {
+		if main.Go != nil {
+			goVersion = main.Go.Version
+		}
+		if main.Toolchain != nil {
+			toolchain = main.Toolchain.Name
+		}
+	}
+
+	wf := &modfile.WorkFile{Syntax: &modfile.FileSyntax{}}
+	wf.Syntax.Stmt = append(wf.Syntax.Stmt, &modfile.CommentBlock{
+		Comments: modfile.Comments{Before: []modfile.Comment{{Token: "// Generated by advncd publish."}}},
+	})
+	if goVersion != "" {
+		if err := wf.AddGoStmt(goVersion); err != nil {
+			return nil, err
+		}
+	}
+	if toolchain != "" {
+		if err := wf.AddToolchainStmt(toolchain); err != nil {
+			return nil, err
+		}
+	}
+	for _, u := range uses {
+		wf.AddNewUse(place(u), "")
+	}
+	for _, rep := range reps {
+		newPath := rep.New.Path
+		if local(rep) {
+			dir := resolvePath(r.work, rep.New.Path)
+			if !contains(needed, dir) {
+				continue
+			}
+			newPath = place(dir)
+		}
+		if err := wf.AddReplace(rep.Old.Path, rep.Old.Version, newPath, rep.New.Version); err != nil {
+			return nil, err
+		}
+	}
+	wf.Cleanup()
+	return modfile.Format(wf.Syntax), nil
+}
+
+// goMod returns the main go.mod with its local replaces of needed
+// modules pointing where place puts them, or nil if none moves.
+func (r *resolver) goMod(module string, needed []string, place func(dir string) string) ([]byte, error) {
+	// A fresh copy: the one loaded is shared.
+	f, err := parseMod(filepath.Join(module, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	type move struct{ old, oldVersion, new string }
+	var moves []move
+	for _, rep := range f.Replace {
+		if !local(rep) {
+			continue
+		}
+		dir := resolvePath(module, rep.New.Path)
+		if p := place(dir); contains(needed, dir) && p != rep.New.Path {
+			moves = append(moves, move{rep.Old.Path, rep.Old.Version, p})
+		}
+	}
+	if len(moves) == 0 {
+		return nil, nil
+	}
+	for _, mv := range moves {
+		if err := f.AddReplace(mv.old, mv.oldVersion, mv.new, ""); err != nil {
+			return nil, err
+		}
+	}
+	f.Cleanup()
+	return f.Format()
+}
+
+// workFile returns the go.work the go command would use in dir.
+func workFile(dir string) (string, error) {
+	switch env := os.Getenv("GOWORK"); {
+	case env == "off":
+		return "", nil
+	case env != "":
+		if !filepath.IsAbs(env) {
+			return "", apperr.New(ErrLayout).
+				WithMeta("GOWORK", env).
+				WithFix("Set GOWORK to an absolute path, or to off.")
+		}
+		return env, nil
+	}
+	if d := findUp(dir, "go.work"); d != "" {
+		return filepath.Join(d, "go.work"), nil
+	}
+	return "", nil
+}
+
+// findUp returns the closest directory from dir upwards holding name.
+func findUp(dir, name string) string {
+	for {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && !info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// commonDir returns the deepest directory containing all of dirs.
+func commonDir(dirs []string) string {
+	common := dirs[0]
+	for _, d := range dirs[1:] {
+		for !within(common, d) {
+			common = filepath.Dir(common)
+		}
+	}
+	return common
+}
+
+func within(root, p string) bool {
+	rel, err := filepath.Rel(root, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func resolvePath(base, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(base, filepath.FromSlash(p))
+}
+
+// relPath is dir relative to root in go.work form: "./svc", or "." for
+// root itself.
+func relPath(root, dir string) string {
+	rel, _ := filepath.Rel(root, dir)
+	if rel == "." {
+		return "."
+	}
+	return "./" + filepath.ToSlash(rel)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package gomod
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTree creates files (slash-separated path -> content) under a temp
+// directory and returns it.
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for rel, data := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func resolve(t *testing.T, root, pkg string) *Layout {
+	t.Helper()
+	l, err := Resolve(filepath.Join(root, filepath.FromSlash(pkg)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestResolveSingleModule(t *testing.T) {
+	t.Setenv("GOWORK", "off")
+	root := writeTree(t, map[string]string{
+		// One-line empty blocks are valid and must not swallow what follows.
+		"go.mod":          "module example.com/app\n\ngo 1.22\n\nrequire ()\nreplace ()\n\nrequire example.com/dep v1.0.0 // indirect\n",
+		"cmd/api/main.go": "package main\n",
+	})
+	l := resolve(t, root, "cmd/api")
+	if l.Root != root || l.ModulePath != "example.com/app" {
+		t.Errorf("Root = %s, ModulePath = %s; want %s, example.com/app", l.Root, l.ModulePath, root)
+	}
+	if l.Dirs != nil || l.GoWork != nil {
+		t.Errorf("Dirs = %v, GoWork = %q; want all of the module and no go.work", l.Dirs, l.GoWork)
+	}
+	if l.Buildable() != "./cmd/api" {
+		t.Errorf("Buildable = %q", l.Buildable())
+	}
+	if m, err := l.AtModule(); err != nil || m != l {
+		t.Errorf("AtModule = %+v, %v; want the layout as is", m, err)
+	}
+}
+
+func TestResolveLocalReplace(t *testing.T) {
+	t.Setenv("GOWORK", "off")
+	root := writeTree(t, map[string]string{
+		"app/go.mod": "module example.com/app\n\ngo 1.22.1\ntoolchain go1.22.5\n\n" +
+			"require (\n\texample.com/lib v0.0.0\n\texample.com/remote v1.0.0\n)\n\n" +
+			"replace example.com/lib => ../lib\n" +
+			"replace example.com/remote v1.0.0 => example.com/fork v1.0.1\n",
+		"app/main.go":    "package main\n",
+		"lib/go.mod":     "module example.com/lib\n\nrequire example.com/util v0.0.0\n\nreplace example.com/util => ../util\n",
+		"util/go.mod":    "module example.com/util\n",
+		"unused/go.mod":  "module example.com/unused\n",
+		"unused/main.go": "package main\n",
+	})
+	l := resolve(t, root, "app")
+	if l.Root != root || l.Module != filepath.Join(root, "app") {
+		t.Errorf("Root = %s, Module = %s", l.Root, l.Module)
+	}
+	// Only the main module's replaces apply: lib's replace of util does
+	// not, so util isn't needed.
+	if want := []string{"app", "lib"}; !reflect.DeepEqual(l.Dirs, want) {
+		t.Errorf("Dirs = %v, want %v", l.Dirs, want)
+	}
+	want := "// Generated by advncd publish.\n\ngo 1.22.1\n\ntoolchain go1.22.5\n\nuse ./app\n"
+	if string(l.GoWork) != want {
+		t.Errorf("go.work:\n%s\nwant:\n%s", l.GoWork, want)
+	}
+	if l.Buildable() != "./app" {
+		t.Errorf("Buildable = %q", l.Buildable())
+	}
+
+	m, err := l.AtModule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Root != l.Module || m.Buildable() != "" || m.Dirs != nil || m.GoWork != nil {
+		t.Errorf("AtModule: Root = %s, Buildable = %q, Dirs = %v, GoWork = %q", m.Root, m.Buildable(), m.Dirs, m.GoWork)
+	}
+	if want := []Extra{{Dir: filepath.Join(root, "lib"), Name: ".advncd/lib"}}; !reflect.DeepEqual(m.Extra, want) {
+		t.Errorf("Extra = %+v, want %+v", m.Extra, want)
+	}
+	wantMod := "module example.com/app\n\ngo 1.22.1\n\ntoolchain go1.22.5\n\n" +
+		"require (\n\texample.com/lib v0.0.0\n\texample.com/remote v1.0.0\n)\n\n" +
+		"replace example.com/lib => ./.advncd/lib\n\n" +
+		"replace example.com/remote v1.0.0 => example.com/fork v1.0.1\n"
+	if string(m.GoMod) != wantMod {
+		t.Errorf("go.mod:\n%s\nwant:\n%s", m.GoMod, wantMod)
+	}
+}
+
+func TestResolveWorkspace(t *testing.T) {
+	t.Setenv("GOWORK", "")
+	root := writeTree(t, map[string]string{
+		"go.work": "go 1.22\n\nuse (\n\t./svc\n\t\"./lib\"\n\t./tools\n)\n\n" +
+			"replace (\n\texample.com/x v1.0.0 => example.com/y v1.1.0\n\texample.com/z => ./tools\n)\n",
+		"go.work.sum":          "example.com/y v1.1.0 h1:abc=\n",
+		"svc/go.mod":           "module example.com/svc\n\ngo 1.22\n\nrequire example.com/lib v0.0.0\n",
+		"svc/cmd/api/main.go":  "package main\n",
+		"lib/go.mod":           "module example.com/lib\n\ngo 1.22\n",
+		"tools/go.mod":         "module example.com/tools\n",
+		"tools/cmd/gen/gen.go": "package main\n",
+	})
+	l := resolve(t, root, "svc/cmd/api")
+	if l.Work != filepath.Join(root, "go.work") || l.Root != root {
+		t.Errorf("Work = %s, Root = %s", l.Work, l.Root)
+	}
+	if want := []string{"lib", "svc"}; !reflect.DeepEqual(l.Dirs, want) {
+		t.Errorf("Dirs = %v, want %v", l.Dirs, want)
+	}
+	want := "// Generated by advncd publish.\n\ngo 1.22\n\nuse (\n\t./svc\n\t./lib\n)\n\n" +
+		"replace example.com/x v1.0.0 => example.com/y v1.1.0\n"
+	if string(l.GoWork) != want {
+		t.Errorf("go.work:\n%s\nwant:\n%s", l.GoWork, want)
+	}
+	if string(l.GoWorkSum) != "example.com/y v1.1.0 h1:abc=\n" {
+		t.Errorf("GoWorkSum = %q", l.GoWorkSum)
+	}
+	if l.Buildable() != "./svc/cmd/api" {
+		t.Errorf("Buildable = %q", l.Buildable())
+	}
+
+	m, err := l.AtModule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Root != filepath.Join(root, "svc") || m.Buildable() != "./cmd/api" || m.GoMod != nil {
+		t.Errorf("AtModule: Root = %s, Buildable = %q, GoMod = %q", m.Root, m.Buildable(), m.GoMod)
+	}
+	if want := []Extra{{Dir: filepath.Join(root, "lib"), Name: ".advncd/lib"}}; !reflect.DeepEqual(m.Extra, want) {
+		t.Errorf("Extra = %+v, want %+v", m.Extra, want)
+	}
+	want = "// Generated by advncd publish.\n\ngo 1.22\n\nuse (\n\t.\n\t./.advncd/lib\n)\n\n" +
+		"replace example.com/x v1.0.0 => example.com/y v1.1.0\n"
+	if string(m.GoWork) != want {
+		t.Errorf("AtModule go.work:\n%s\nwant:\n%s", m.GoWork, want)
+	}
+	if string(m.GoWorkSum) != string(l.GoWorkSum) {
+		t.Errorf("AtModule GoWorkSum = %q", m.GoWorkSum)
+	}
+}
+
+func TestResolveWorkspaceAtModuleRoot(t *testing.T) {
+	t.Setenv("GOWORK", "")
+	root := writeTree(t, map[string]string{
+		"go.work":      "go 1.22\n\nuse (\n\t.\n\t./lib\n\t./tools\n)\n",
+		"go.mod":       "module example.com/app\n\nrequire example.com/lib v0.0.0\n",
+		"main.go":      "package main\n",
+		"lib/go.mod":   "module example.com/lib\n",
+		"tools/go.mod": "module example.com/tools\n",
+	})
+	l := resolve(t, root, ".")
+	if l.Buildable() != "" || l.Root != root {
+		t.Errorf("Buildable = %q, Root = %s; want the root module", l.Buildable(), l.Root)
+	}
+	if want := []string{".", "lib"}; !reflect.DeepEqual(l.Dirs, want) {
+		t.Errorf("Dirs = %v, want %v", l.Dirs, want)
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	t.Setenv("GOWORK", "")
+	cases := []struct {
+		name  string
+		files map[string]string
+		pkg   string
+		want  error
+	}{
+		{"no go.mod", map[string]string{"main.go": "package main\n"}, ".", ErrNoModule},
+		{"no module line", map[string]string{"go.mod": "go 1.22\n"}, ".", ErrLayout},
+		{"unterminated block", map[string]string{"go.mod": "module example.com/app\n\nrequire (\n\texample.com/lib v0.0.0\n"}, ".", ErrLayout},
+		{"module outside the workspace", map[string]string{
+			"go.work":    "go 1.22\n\nuse ./lib\n",
+			"lib/go.mod": "module example.com/lib\n",
+			"app/go.mod": "module example.com/app\n",
+		}, "app", ErrLayout},
+		{"workspace module without go.mod", map[string]string{
+			"go.work":    "go 1.22\n\nuse (\n\t./app\n\t./gone\n)\n",
+			"app/go.mod": "module example.com/app\n",
+		}, "app", ErrLayout},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Resolve(filepath.Join(writeTree(t, c.files), filepath.FromSlash(c.pkg)))
+			if !errors.Is(err, c.want) {
+				t.Errorf("err = %v, want %v", err, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package gomod
+
+import (
+	"os"
+
+	"golang.org/x/mod/modfile"
+)
+
+// parseMod reads the go.mod at path as the go command reads a main
+// module's: strictly, replaces included.
+func parseMod(path string) (*modfile.File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return modfile.Parse(path, data, nil)
+}
+
+// parseWork reads the go.work at path.
+func parseWork(path string) (*modfile.WorkFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return modfile.ParseWork(path, data, nil)
+}
@@ -0,0 +1,22 @@
+package i18n
+
+// entryText is the translation of an apperr catalog entry. Title is only
+// needed when the English entry has a title of its own.
+type entryText struct {
+	Message string
+	Title   string
+	Summary string
+}
+
+// entries and messages hold the catalogs of every non-English locale:
+// error entries by code, everything else by English text. Keys with verbs
+// (%s, %q, %d) also match already formatted strings in Text; their
+// translation must use the same verbs in the same order.
+var (
+	entries = map[string]map[string]entryText{
+		Ru: ruEntries,
+	}
+	messages = map[string]map[string]string{
+		Ru: ruMessages,
+	}
+)
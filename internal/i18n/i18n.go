@@ -0,0 +1,208 @@
+// Package i18n translates user-facing CLI text. English is the source
+// language: messages are looked up by their English text (gettext style),
+// so untranslated strings simply print in English.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/debug"
+)
+
+const (
+	En = "en"
+	Ru = "ru"
+)
+
+// Supported lists the locales with a catalog, English first.
+var Supported = []string{En, Ru}
+
+var current = En
+
+// Lang returns the active locale.
+func Lang() string { return current }
+
+// Set switches the active locale; unsupported values fall back to English.
+func Set(lang string) {
+	current = Normalize(lang)
+	if current == "" {
+		current = En
+	}
+}
+
+// Detect picks the locale: ADVNCD_LANG, then the config's lang, then the
+// usual POSIX variables (LC_ALL, LC_MESSAGES, LANG). The first value that
+// names a supported locale wins; C/POSIX and unknown values are skipped.
+func Detect(configLang string) string {
+	for _, v := range []string{
+		os.Getenv("ADVNCD_LANG"),
+		configLang,
+		os.Getenv("LC_ALL"),
+		os.Getenv("LC_MESSAGES"),
+		os.Getenv("LANG"),
+	} {
+		if l := Normalize(v); l != "" {
+			return l
+		}
+	}
+	return En
+}
+
+// Normalize maps "ru_RU.UTF-8", "ru-RU" or "RU" to "ru". Returns "" for
+// locales we don't ship.
+func Normalize(v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if i := strings.IndexAny(v, "_-.@"); i >= 0 {
+		v = v[:i]
+	}
+	for _, l := range Supported {
+		if v == l {
+			return l
+		}
+	}
+	return ""
+}
+
+// T translates format and applies args, like fmt.Sprintf. Use it for text
+// written by the CLI itself.
+func T(format string, args ...any) string {
+	if tr, ok := lookup(format); ok {
+		format = tr
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Text translates an already formatted string, such as a fix hint built
+// with string concatenation: an exact catalog match first, then catalog
+// keys with verbs (%s, %q, %d) used as patterns, then error catalog
+// messages.
+func Text(s string) string {
+	if s == "" || current == En {
+		return s
+	}
+	if tr, ok := lookup(s); ok {
+		return tr
+	}
+	for _, p := range patterns(current) {
+		m := p.re.FindStringSubmatch(s)
+		if m == nil {
+			continue
+		}
+		args := make([]any, len(m)-1)
+		for i, a := range m[1:] {
+			args[i] = a
+		}
+		return fmt.Sprintf(p.tr, args...)
+	}
+	// Error messages used as text, e.g. a preflight check's detail.
+	for _, e := range apperr.All() {
+		if e.Message == s {
+			return Entry(e).Message
+		}
+	}
+	debug.Logf("i18n: no %s translation for %q", current, s)
+	return s
+}
+
+// Entry returns e with Message, Title and Summary in the active locale.
+func Entry(e apperr.Entry) apperr.Entry {
+	if current == En {
+		return e
+	}
+	tr, ok := entries[current][e.Code]
+	if !ok {
+		debug.Logf("i18n: no %s translation for %s", current, e.Code)
+		return e
+	}
+	titled := e.Title != e.Message
+	if tr.Message != "" {
+		e.Message = tr.Message
+	}
+	if tr.Summary != "" {
+		e.Summary = tr.Summary
+	}
+	if tr.Title != "" {
+		e.Title = tr.Title
+	} else if !titled {
+		e.Title = e.Message
+	}
+	return e
+}
+
+// Message translates an apperr.Error message: the catalog entry's
+// translation when the message is the entry's own, Text otherwise.
+func Message(code, msg string) string {
+	if current == En {
+		return msg
+	}
+	if e, ok := apperr.Lookup(code); ok && e.Message == msg {
+		return Entry(e).Message
+	}
+	return Text(msg)
+}
+
+func lookup(s string) (string, bool) {
+	if current == En {
+		return "", false
+	}
+	tr, ok := messages[current][s]
+	return tr, ok && tr != ""
+}
+
+type pattern struct {
+	key string
+	re  *regexp.Regexp
+	tr  string // translation with every verb turned into %s
+}
+
+var (
+	patternsMu sync.Mutex
+	compiled   = map[string][]pattern{}
+)
+
+var verbRe = regexp.MustCompile(`%[sqdv]`)
+
+// patterns compiles catalog keys that contain verbs into regexps that
+// capture the substituted values.
+func patterns(lang string) []pattern {
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+	if ps, ok := compiled[lang]; ok {
+		return ps
+	}
+	var ps []pattern
+	for k, tr := range messages[lang] {
+		if !verbRe.MatchString(k) || tr == "" {
+			continue
+		}
+		expr := "^" + verbRe.ReplaceAllStringFunc(regexp.QuoteMeta(k), func(v string) string {
+			switch v {
+			case "%d":
+				return `(-?\d+)`
+			case "%q":
+				return `(".*?")`
+			}
+			return `(.+?)`
+		}) + "$"
+		ps = append(ps, pattern{key: k, re: regexp.MustCompile(expr), tr: verbRe.ReplaceAllString(tr, "%s")})
+	}
+	// Most specific first: "skew %s; tokens will be rejected" must win
+	// over "skew %s".
+	sort.Slice(ps, func(i, j int) bool {
+		if a, b := len(ps[i].key), len(ps[j].key); a != b {
+			return a > b
+		}
+		return ps[i].key < ps[j].key
+	})
+	compiled[lang] = ps
+	return ps
+}
@@ -0,0 +1,186 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// repoRoot is the module root, seen from this package's directory.
+const repoRoot = "../.."
+
+// sourceStrings holds the catalog text found in the repository's Go files.
+type sourceStrings struct {
+	entries  map[string]entryText // apperr.E definitions by code
+	messages map[string]string    // i18n.T format literals -> position
+}
+
+// scanSource parses every non-test Go file of the module and collects
+// apperr.E(code, message, WithTitle(..), WithSummary(..)) definitions and
+// the string literal formats passed to i18n.T.
+func scanSource(t *testing.T) sourceStrings {
+	t.Helper()
+	src := sourceStrings{entries: map[string]entryText{}, messages: map[string]string{}}
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(repoRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != repoRoot && (strings.HasPrefix(name, ".") || name == "testdata" || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		pkg := f.Name.Name
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			switch callee(call, pkg) {
+			case "apperr.E":
+				code, ok1 := stringArg(call, 0)
+				msg, ok2 := stringArg(call, 1)
+				if !ok1 || !ok2 {
+					return true
+				}
+				e := entryText{Message: msg}
+				for _, opt := range call.Args[2:] {
+					oc, ok := opt.(*ast.CallExpr)
+					if !ok {
+						continue
+					}
+					v, ok := stringArg(oc, 0)
+					if !ok {
+						continue
+					}
+					switch callee(oc, pkg) {
+					case "apperr.WithTitle":
+						e.Title = v
+					case "apperr.WithSummary":
+						e.Summary = v
+					}
+				}
+				src.entries[code] = e
+			case "i18n.T":
+				if s, ok := stringArg(call, 0); ok {
+					src.messages[s] = fset.Position(call.Pos()).String()
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(src.entries) == 0 || len(src.messages) == 0 {
+		t.Fatalf("found %d catalog entries and %d messages; is repoRoot right?", len(src.entries), len(src.messages))
+	}
+	return src
+}
+
+// callee names the function called as "pkg.Func", qualifying calls made
+// inside the package itself.
+func callee(call *ast.CallExpr, pkg string) string {
+	switch fn := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		if id, ok := fn.X.(*ast.Ident); ok {
+			return id.Name + "." + fn.Sel.Name
+		}
+	case *ast.Ident:
+		return pkg + "." + fn.Name
+	}
+	return ""
+}
+
+func stringArg(call *ast.CallExpr, i int) (string, bool) {
+	if i >= len(call.Args) {
+		return "", false
+	}
+	lit, ok := call.Args[i].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+func TestEntriesTranslated(t *testing.T) {
+	src := scanSource(t)
+	for _, lang := range Supported[1:] {
+		for code, e := range src.entries {
+			tr, ok := entries[lang][code]
+			switch {
+			case !ok:
+				t.Errorf("%s: %s (%q): no translation", lang, code, e.Message)
+				continue
+			case tr.Message == "":
+				t.Errorf("%s: %s: no message", lang, code)
+			}
+			if e.Summary != "" && tr.Summary == "" {
+				t.Errorf("%s: %s: no summary", lang, code)
+			}
+			if e.Title != "" && e.Title != e.Message && tr.Title == "" {
+				t.Errorf("%s: %s: no title", lang, code)
+			}
+		}
+		for code := range entries[lang] {
+			if _, ok := src.entries[code]; !ok {
+				t.Errorf("%s: %s: translation for an unknown code", lang, code)
+			}
+		}
+	}
+}
+
+func TestMessagesTranslated(t *testing.T) {
+	src := scanSource(t)
+	for _, lang := range Supported[1:] {
+		for msg, pos := range src.messages {
+			if messages[lang][msg] == "" {
+				t.Errorf("%s: %s: no translation for %q", lang, pos, msg)
+			}
+		}
+	}
+}
+
+// TestCatalogConsistent runs the checks of `advncd i18n missing` on the
+// entries registered by this package's imports, and the verb check on
+// every message.
+func TestCatalogConsistent(t *testing.T) {
+	for _, m := range Missing() {
+		if strings.Contains(m, "unknown code") {
+			continue // codes of packages this test doesn't import
+		}
+		t.Error(m)
+	}
+}
+
+func TestText(t *testing.T) {
+	defer Set(En)
+	Set(Ru)
+	cases := []struct{ in, want string }{
+		{"Upload source", "Загрузка исходников"},
+		{"skew 3s; tokens will be rejected", "расхождение 3s; токены будут отклонены"},
+		{"skew 3s", "расхождение 3s"},
+		{"not in the catalog", "not in the catalog"},
+	}
+	for _, c := range cases {
+		if got := Text(c.in); got != c.want {
+			t.Errorf("Text(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
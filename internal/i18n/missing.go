@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+// Missing lists catalog problems for every non-English locale: error codes
+// without a translated message or summary, translations for codes that no
+// longer exist, empty translations and translations whose verbs don't
+// match the English text. Empty means the catalogs are complete; it is
+// what `advncd i18n missing` checks. Strings passed to T are covered by
+// the package tests, which scan the sources for them.
+func Missing() []string {
+	var out []string
+	for _, lang := range Supported[1:] {
+		known := map[string]bool{}
+		for _, e := range apperr.All() {
+			known[e.Code] = true
+			tr, ok := entries[lang][e.Code]
+			switch {
+			case !ok:
+				out = append(out, fmt.Sprintf("%s: %s: no translation", lang, e.Code))
+				continue
+			case tr.Message == "":
+				out = append(out, fmt.Sprintf("%s: %s: no message", lang, e.Code))
+			}
+			if e.Summary != "" && tr.Summary == "" {
+				out = append(out, fmt.Sprintf("%s: %s: no summary", lang, e.Code))
+			}
+			if e.Title != e.Message && tr.Title == "" {
+				out = append(out, fmt.Sprintf("%s: %s: no title", lang, e.Code))
+			}
+		}
+		for code := range entries[lang] {
+			if !known[code] {
+				out = append(out, fmt.Sprintf("%s: %s: unknown code", lang, code))
+			}
+		}
+		for k, tr := range messages[lang] {
+			if tr == "" {
+				out = append(out, fmt.Sprintf("%s: %q: empty translation", lang, k))
+				continue
+			}
+			if a, b := verbs(k), verbs(tr); a != b {
+				out = append(out, fmt.Sprintf("%s: %q: verbs %s, translation has %s", lang, k, a, b))
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func verbs(s string) string {
+	v := verbRe.FindAllString(s, -1)
+	if len(v) == 0 {
+		return "none"
+	}
+	return strings.Join(v, " ")
+}
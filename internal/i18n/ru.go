@@ -0,0 +1,504 @@
+package i18n
+
+var ruEntries = map[string]entryText{
+	// A: auth and credentials
+	"A-AUTH-001": {Message: "Не задан OAuth client ID Google",
+		Summary: "OAuth client ID не настроен, поэтому вход не может начаться."},
+	"A-AUTH-002": {Message: "Неверные OAuth scopes",
+		Summary: "Запрошенные OAuth scopes пусты или заданы неверно."},
+	"A-AUTH-010": {Message: "Не удалось собрать HTTP-запрос",
+		Summary: "HTTP-запрос к OAuth-эндпоинтам Google не удалось собрать."},
+	"A-AUTH-011": {Message: "Не удалось выполнить HTTP-запрос",
+		Summary: "OAuth-эндпоинты Google недоступны."},
+	"A-AUTH-012": {Message: "Не удалось разобрать JSON-ответ",
+		Summary: "OAuth-эндпоинты Google вернули ответ, который advncd не смог разобрать."},
+	"A-AUTH-100": {Message: "Запрос Google OAuth Device Flow не удался",
+		Summary: "Google отклонил запрос device code."},
+	"A-AUTH-101": {Message: "Некорректный ответ Google OAuth Device Flow",
+		Summary: "В ответе device code нет обязательных полей."},
+	"A-AUTH-200": {Message: "Не удалось сгенерировать PKCE verifier/challenge",
+		Summary: "Не удалось сгенерировать PKCE-секрет для входа через браузер."},
+	"A-AUTH-201": {Message: "Не удалось сгенерировать OAuth state",
+		Summary: "Не удалось сгенерировать anti-CSRF state для входа через браузер."},
+	"A-AUTH-202": {Message: "Не удалось запустить callback-сервер на localhost",
+		Summary: "Не удалось открыть локальный порт для callback входа через браузер."},
+	"A-AUTH-203": {Message: "Ошибка callback-сервера",
+		Summary: "Локальный callback-сервер остановился до завершения входа."},
+	"A-AUTH-204": {Message: "Не удалось собрать OAuth URL авторизации",
+		Summary: "Не удалось собрать URL входа в Google."},
+	"A-AUTH-205": {Message: "Время входа истекло",
+		Summary: "Вход в браузере не был завершён вовремя."},
+	"A-AUTH-210": {Message: "OAuth state не совпадает",
+		Summary: "State в callback не совпадает с попыткой входа; он устарел или подделан."},
+	"A-AUTH-211": {Message: "Авторизация отклонена",
+		Summary: "Доступ отклонён на экране согласия Google."},
+	"A-AUTH-212": {Message: "В callback нет кода авторизации",
+		Summary: "Google вернул redirect без кода авторизации."},
+	"A-AUTH-300": {Message: "Не удалось обменять код авторизации на токены",
+		Summary: "Google не обменял код авторизации на токены."},
+	"A-AUTH-301": {Message: "Не удалось получить данные пользователя",
+		Summary: "Не удалось прочитать e-mail аккаунта с новым токеном."},
+	"A-AUTH-402": {Message: "Вход не выполнен", Title: "GCP не подключён",
+		Summary: "Креды не найдены; командам, которые обращаются к Google Cloud, нужен вход."},
+	"A-AUTH-403": {Message: "Недостаточно OAuth scopes",
+		Summary: "У сохранённого токена нет scope cloud-platform, который нужен всем API Google Cloud."},
+	"A-CREDS-001": {Message: "Не удалось прочитать креды",
+		Summary: "Файл кредов существует, но его не удалось прочитать или разобрать."},
+	"A-CREDS-002": {Message: "Не удалось записать креды",
+		Summary: "Не удалось записать файл кредов."},
+	"A-CREDS-003": {Message: "Не удалось удалить креды",
+		Summary: "Не удалось удалить файл кредов."},
+	"A-CREDS-004": {Message: "Креды записаны более новой версией advncd",
+		Summary: "Версия схемы файла кредов новее, чем понимает этот advncd."},
+	"A-CREDS-005": {Message: "Не удалось обновить креды до текущего формата",
+		Summary: "Старый файл кредов не удалось обновить до текущей схемы."},
+
+	// B: config, project, APIs, billing, input
+	"B-BILLING-001": {Message: "Не удалось привязать billing-аккаунт",
+		Summary: "Привязка billing-аккаунта к проекту отклонена."},
+	"B-BILLING-002": {Message: "Billing не включён для этого проекта", Title: "Billing выключен",
+		Summary: "Cloud Build, Artifact Registry и Cloud Run не работают без billing."},
+	"B-BILLING-003": {Message: "Не удалось проверить billing проекта",
+		Summary: "Не удалось прочитать статус billing проекта."},
+	"B-BILLING-004": {Message: "Не удалось получить список billing-аккаунтов",
+		Summary: "Не удалось получить список доступных вам billing-аккаунтов."},
+	"B-CLI-001": {Message: "Неподдерживаемый формат вывода",
+		Summary: "Эта команда не поддерживает запрошенный формат вывода."},
+	"B-CLI-002": {Message: "Неизвестный код ошибки",
+		Summary: "Этого кода нет в каталоге ошибок этой версии."},
+	"B-CLI-003": {Message: "Каталог сообщений неполный",
+		Summary: "Для части кодов ошибок или сообщений нет перевода на один из поддерживаемых языков."},
+	"B-CONFIG-001": {Message: "Не удалось прочитать конфиг",
+		Summary: "Файл конфига существует, но его не удалось прочитать или разобрать."},
+	"B-CONFIG-002": {Message: "Не удалось записать конфиг",
+		Summary: "Не удалось записать файл конфига."},
+	"B-CONFIG-003": {Message: "Не удалось удалить конфиг",
+		Summary: "Не удалось удалить файл конфига."},
+	"B-CONFIG-004": {Message: "Конфиг записан более новой версией advncd",
+		Summary: "Версия схемы файла конфига новее, чем понимает этот advncd."},
+	"B-CONFIG-005": {Message: "Не удалось обновить конфиг до текущего формата",
+		Summary: "Старый файл конфига не удалось обновить до текущей схемы."},
+	"B-CONFIG-006": {Message: "Проект и регион не заданы", Title: "Проект или регион GCP не задан",
+		Summary: "Задайте проект и регион по умолчанию, чтобы деплоить и смотреть ресурсы."},
+	"B-CONFIG-007": {Message: "Недопустимое значение в конфиге",
+		Summary: "Поле в файле конфига содержит значение, которое advncd не принимает."},
+	"B-CRM-001": {Message: "Не удалось получить список проектов GCP",
+		Summary: "Resource Manager не вернул список проектов."},
+	"B-CRM-002": {Message: "Не удалось получить информацию о проекте GCP",
+		Summary: "Проект не существует, ID введён с ошибкой или у аккаунта нет к нему доступа."},
+	"B-CRM-003": {Message: "Не удалось выполнить поиск проектов GCP",
+		Summary: "Поиск проектов в Resource Manager не удался."},
+	"B-CRM-004": {Message: "Не удалось создать проект GCP",
+		Summary: "Resource Manager отклонил новый проект."},
+	"B-CRM-005": {Message: "Не удалось дождаться операции над проектом",
+		Summary: "Операция создания проекта завершилась ошибкой или не закончилась."},
+	"B-CRM-006": {Message: "Неверный ID проекта GCP",
+		Summary: "ID проекта — 6–30 строчных букв, цифр или дефисов, начинается с буквы."},
+	"B-CRM-007": {Message: "Не удалось проверить IAM-права на проект",
+		Summary: "Resource Manager не смог проверить, какие права у вас есть."},
+	"B-CRM-008": {Message: "Не удалось прочитать IAM-политику проекта",
+		Summary: "IAM-политику проекта не удалось прочитать."},
+	"B-DOCTOR-001": {Message: "Проверки окружения не пройдены",
+		Summary: "Одна или несколько проверок doctor не пройдены; подробности в таблице."},
+	"B-INPUT-001": {Message: "Нужен интерактивный ввод",
+		Summary: "Не хватает значения, а запросы отключены или нет терминала."},
+	"B-INPUT-002": {Message: "Ввод закрыт до того, как был сделан выбор",
+		Summary: "Стандартный ввод закончился, пока advncd ждал ответа."},
+	"B-INPUT-003": {Message: "Выбор отменён",
+		Summary: "Выбор отменён клавишей Esc или Ctrl-C."},
+	"B-INPUT-004": {Message: "Недопустимое значение флага",
+		Summary: "Флагу передано значение, которое он не принимает."},
+	"B-TIMEOUT-001": {Message: "Время команды истекло",
+		Summary: "Команда не уложилась в свой лимит времени; начатая в Google Cloud работа может ещё идти."},
+	"B-REGION-001": {Message: "Неверный регион",
+		Summary: "Регион не распознан или недоступен для Cloud Run в этом проекте."},
+	"B-SU-001": {Message: "Не удалось проверить статус API", Title: "Не удалось проверить включённые API",
+		Summary: "Service Usage не смог сообщить, включён ли API."},
+	"B-SU-002": {Message: "Не удалось включить API",
+		Summary: "Service Usage отклонил запрос на включение API."},
+	"B-SU-003": {Message: "Не удалось дождаться включения API",
+		Summary: "Операция включения API завершилась ошибкой или не закончилась."},
+	"B-SU-004": {Message: "Нужные API Google не включены",
+		Summary: "Часть сервисов, от которых зависит publish, выключена в этом проекте."},
+	"B-SU-005": {Message: "Не удалось получить список включённых API", Title: "Не удалось проверить включённые API",
+		Summary: "Service Usage не вернул список включённых API."},
+
+	// C: build, storage, registry, run, publish
+	"C-AR-001": {Message: "Не удалось проверить репозиторий Artifact Registry",
+		Summary: "Не удалось найти репозиторий Artifact Registry."},
+	"C-AR-002": {Message: "Не удалось создать репозиторий Artifact Registry",
+		Summary: "Репозиторий Artifact Registry не удалось создать."},
+	"C-AR-003": {Message: "Не удалось получить список локаций Artifact Registry",
+		Summary: "Artifact Registry не вернул список локаций."},
+	"C-BUILD-001": {Message: "Не удалось запустить Cloud Build",
+		Summary: "Не удалась загрузка исходников или запрос к Cloud Build; причина ниже."},
+	"C-BUILD-002": {Message: "Не удалось опросить Cloud Build",
+		Summary: "Не удалось прочитать статус сборки во время ожидания."},
+	"C-BUILD-003": {Message: "Сборка не удалась", Title: "Ошибка сборки",
+		Summary: "Cloud Build завершился со статусом ошибки; причина в логах сборки."},
+	"C-BUILD-004": {Message: "Не удалось получить сборку Cloud Build",
+		Summary: "Сборку не удалось прочитать; проверьте ID и регион."},
+	"C-BUILD-005": {Message: "Не удалось получить список сборок Cloud Build",
+		Summary: "Cloud Build не вернул сборки в этом регионе."},
+	"C-BUILD-006": {Message: "Элемент исходников указывает за пределы папки",
+		Summary: "Симлинк (или путь в архиве) ведёт за пределы публикуемой папки, которую видит сборка."},
+	"C-GCS-001": {Message: "Не удалось загрузить исходники в Cloud Storage",
+		Summary: "Архив с исходниками не удалось загрузить в Cloud Storage."},
+	"C-GCS-002": {Message: "Не удалось создать бакет Cloud Storage",
+		Summary: "Не удалось создать бакет для исходников Cloud Build."},
+	"C-GCS-003": {Message: "Не удалось проверить права на бакет Cloud Storage",
+		Summary: "Cloud Storage не смог проверить ваши права на бакет."},
+	"C-GCS-004": {Message: "Ошибка запроса к объекту Cloud Storage",
+		Summary: "Cloud Storage не смог проверить или удалить загруженный архив исходников."},
+	"C-PUBLISH-001": {Message: "Publish остановлен предварительными проверками",
+		Summary: "Предварительная проверка не пройдена, поэтому ничего не загружено и не задеплоено."},
+	"C-PUBLISH-002": {Message: "Это не Go-модуль",
+		Summary: "publish собирает пакет Go-модуля, а go.mod нет ни в его папке, ни выше."},
+	"C-PUBLISH-003": {Message: "Не удалось определить имя сервиса",
+		Summary: "Из имени папки не получается корректное имя сервиса Cloud Run."},
+	"C-PUBLISH-004": {Message: "Не удалось разобрать структуру Go-модуля",
+		Summary: "go.mod или go.work не читается или указывает на папку, которую publish не может использовать."},
+	"C-PUBLISH-005": {Message: "Нет Dockerfile для сборки",
+		Summary: "Выбрана сборка через docker, но Dockerfile нет ни в папке приложения, ни выше."},
+	"C-RUN-001": {Message: "Не удалось получить сервис Cloud Run",
+		Summary: "Сервис Cloud Run не удалось прочитать."},
+	"C-RUN-002": {Message: "Не удалось задеплоить сервис Cloud Run",
+		Summary: "Cloud Run отклонил создание или обновление сервиса."},
+	"C-RUN-003": {Message: "Не удалось дождаться операции Cloud Run",
+		Summary: "Операция Cloud Run завершилась ошибкой или не закончилась."},
+	"C-RUN-004": {Message: "Не удалось настроить IAM Cloud Run",
+		Summary: "IAM-политику сервиса Cloud Run не удалось обновить."},
+	"C-RUN-005": {Message: "Не удалось получить список локаций Cloud Run",
+		Summary: "Cloud Run не вернул список локаций."},
+	"C-RUN-006": {Message: "Не удалось получить список сервисов Cloud Run",
+		Summary: "Cloud Run не вернул сервисы в этом регионе."},
+}
+
+var ruMessages = map[string]string{
+	// Error output
+	"Error":      "Ошибка",
+	"Details:":   "Подробности:",
+	"Caused by:": "Причина:",
+	"Fix:":       "Как исправить:",
+
+	// Prompts and login
+	"Please answer y or n.":                                       "Ответьте y или n.",
+	"To authenticate, visit this URL in your browser:":            "Для входа откройте этот URL в браузере:",
+	"Then enter this code:":                                       "Затем введите код:",
+	"Code expires in %d seconds. Poll interval: %d seconds.":      "Код действует %d с. Интервал опроса: %d с.",
+	"Authorization callback received":                             "Callback авторизации получен",
+	"Starting local callback server...":                           "Запуск локального callback-сервера...",
+	"Opening browser for authentication...":                       "Открываем браузер для входа...",
+	"Could not open browser automatically. Please open this URL:": "Не удалось открыть браузер автоматически. Откройте этот URL:",
+	"Waiting for authentication to complete in browser...":        "Ждём завершения входа в браузере...",
+	"Exchanging authorization code for tokens...":                 "Обмениваем код авторизации на токены...",
+	"Fetching user info...":                                       "Получаем данные пользователя...",
+	"Logged in as %s":                                             "Вход выполнен: %s",
+	"Logged in":                                                   "Вход выполнен",
+	"Warning: refresh_token is empty.":                            "Внимание: refresh_token пустой.",
+	"This can happen if Google doesn't re-issue refresh tokens on repeated consents.": "Так бывает, если Google не выдаёт refresh token повторно при повторном согласии.",
+	"If future commands fail after token expiry, run: advncd login":                   "Если команды начнут падать после истечения токена, выполните: advncd login",
+	"Saved credentials: %s":                  "Креды сохранены: %s",
+	"Logged out (local credentials removed)": "Выход выполнен (локальные креды удалены)",
+
+	// init
+	"No ACTIVE projects found for this account.":                                        "У этого аккаунта нет проектов в статусе ACTIVE.",
+	"You can still set a project manually:":                                             "Проект можно задать вручную:",
+	"Could not load regions for this project; showing common regions.":                  "Не удалось загрузить регионы проекта; показываем основные регионы.",
+	"Loading GCP projects":                                                              "Загрузка проектов GCP",
+	"First %d projects; type part of an ID or name and press Enter to search the rest.": "Первые %d проектов; введите часть ID или названия и нажмите Enter, чтобы искать среди остальных.",
+	"Select Cloud Run service:":                                                         "Выберите сервис Cloud Run:",
+	"Type a new name to create a service.":                                              "Введите новое имя, чтобы создать сервис.",
+	"(no matches; Enter uses %q)":                                                       "(ничего не найдено; Enter — использовать %q)",
+	"%d/%d · ↑↓ move · type to filter · Enter select · Esc cancel":                      "%d/%d · ↑↓ выбор · ввод — фильтр · Enter — выбрать · Esc — отмена",
+	"%d/%d · ↑↓ move · type to filter · Enter search · Esc cancel":                      "%d/%d · ↑↓ выбор · ввод — фильтр · Enter — искать · Esc — отмена",
+	"Searching %q...": "Поиск %q...",
+	"page %d/%d":      "стр. %d/%d",
+	"(showing first %d of %d; type text to filter)": "(показаны первые %d из %d; введите текст для фильтра)",
+	"Enter number, value, or text to filter: ":      "Введите номер, значение или текст для фильтра: ",
+	"Select GCP project:":                           "Выберите проект GCP:",
+	"(no matches)":                                  "(ничего не найдено)",
+	"Invalid choice.":                               "Неверный выбор.",
+	"Select region:":                                "Выберите регион:",
+	"Did you mean: %s?":                             "Возможно, вы имели в виду: %s?",
+	"Project set: %s":                               "Проект:  %s",
+	"Region set:  %s":                               "Регион:  %s",
+	"Saved config: %s":                              "Конфиг сохранён: %s",
+
+	// gcp project, region and billing
+	"Region set: %s":                  "Регион: %s",
+	"Next:":                           "Дальше:",
+	"Billing account %s linked to %s": "Billing-аккаунт %s привязан к %s",
+	"Creating project %s...":          "Создание проекта %s...",
+	"Project created":                 "Проект создан",
+	"Active project: %s":              "Активный проект: %s",
+	"No --billing-account given; skipping API enablement and repository setup.": "--billing-account не задан; включение API и создание репозитория пропущены.",
+	"Link billing in GCP Console → Billing, then run: advncd status":            "Привяжите billing в GCP Console → Billing, затем выполните: advncd status",
+	"Linking billing account...": "Привязка billing-аккаунта...",
+	"Billing linked":             "Billing привязан",
+	"No region set; skipping Artifact Registry repository (publish creates it on first run).": "Регион не задан; репозиторий Artifact Registry пропущен (publish создаст его при первом запуске).",
+	"Creating Artifact Registry repo %s in %s...":                                             "Создание репозитория Artifact Registry %s в %s...",
+	"Repository ready":                                          "Репозиторий готов",
+	"Project %s is ready. Next:":                                "Проект %s готов. Дальше:",
+	"PROJECT_ID\tNAME\tPARENT":                                  "ID ПРОЕКТА\tНАЗВАНИЕ\tРОДИТЕЛЬ",
+	"(showing first %d; narrow with --filter or raise --limit)": "(показаны первые %d; уточните --filter или увеличьте --limit)",
+
+	// builds and errors
+	"BUILD_ID\tSTATUS\tCREATED": "ID СБОРКИ\tСТАТУС\tСОЗДАНА",
+	"build:    %s":              "сборка:    %s",
+	"status:   %s":              "статус:    %s",
+	"created:  %s":              "создана:   %s",
+	"finished: %s":              "завершена: %s",
+	"image:    %s":              "образ:     %s",
+	"logs:     %s":              "логи:      %s",
+	"STATUS\tCHECK\tDETAIL":     "СТАТУС\tПРОВЕРКА\tДЕТАЛИ",
+	"CODE\tSEVERITY\tTITLE":     "КОД\tУРОВЕНЬ\tЗАГОЛОВОК",
+	"severity: %s":              "уровень:   %s",
+	"message:  %s":              "сообщение: %s",
+	"docs: %s":                  "документация: %s",
+
+	// status
+	"fix: run `advncd apis enable`":                                           "исправить: выполните `advncd apis enable`",
+	"fix: or enable them in Google Cloud Console → APIs & Services → Library": "исправить: или включите их в Google Cloud Console → APIs & Services → Library",
+	"fix: run `advncd init`":                                                  "исправить: выполните `advncd init`",
+	"region is not available for Cloud Run in this project":                   "регион недоступен для Cloud Run в этом проекте",
+	"did you mean: %s":                                                        "возможно, вы имели в виду: %s",
+	"fix: run `advncd init --region <region>`":                                "исправить: выполните `advncd init --region <region>`",
+	"(unable to resolve project number; skipping API checks)":                 "(не удалось получить номер проекта; проверка API пропущена)",
+	"fix: ensure you have access to this project":                             "исправить: проверьте, что у вас есть доступ к проекту",
+	"auth: ok":              "вход: ok",
+	"email: %s":             "e-mail: %s",
+	"token expires in: %s":  "токен истекает через: %s",
+	"credentials: %s":       "креды: %s",
+	"config: not set":       "конфиг: не задан",
+	"config path: %s":       "путь к конфигу: %s",
+	"project: %s":           "проект:  %s",
+	"region: %s":            "регион: %s",
+	"config: %s":            "конфиг: %s",
+	"billing: enabled (%s)": "billing: включён (%s)",
+	"billing: disabled":     "billing: выключен",
+	"billing: unknown (unable to read billing info)": "billing: неизвестно (не удалось прочитать данные billing)",
+	"fix: %s":  "исправить: %s",
+	"APIs:":    "API:",
+	"enabled":  "включён",
+	"disabled": "выключен",
+	"unknown":  "неизвестно",
+	"missing:": "не хватает:",
+
+	// apis enable
+	"Checking required APIs...":                              "Проверяем нужные API...",
+	"All required APIs are already enabled":                  "Все нужные API уже включены",
+	"Enabling %d API(s) in %s (this can take a few minutes)": "Включение API (%d) в %s (может занять несколько минут)",
+	"waiting":                       "ожидание",
+	"Enable %d missing API(s) now?": "Включить недостающие API (%d) сейчас?",
+
+	// publish
+	"warning: %s": "внимание: %s",
+	"skipped %s: not a regular file, directory or symlink (%s)": "пропущен %s: не обычный файл, папка или симлинк (%s)",
+	"skipped %s: symlink to %s is outside the source directory": "пропущен %s: симлинк на %s вне папки исходников",
+	"skipped %s: link target %s is not a regular file":          "пропущен %s: цель ссылки %s — не обычный файл",
+	"skipped %s: symlink loop through %s":                       "пропущен %s: цикл симлинков через %s",
+	"%s, unchanged since an earlier upload":                     "%s, не изменился с прошлой загрузки",
+	"Ignore files: none (defaults only: .git/, /advncd, /bin/)": "Ignore-файлы: нет (только исключения по умолчанию: .git/, /advncd, /bin/)",
+	"Ignore files: %s":                                     "Ignore-файлы: %s",
+	"%d files, %s; archive %s":                             "Файлов: %d, %s; архив %s",
+	"Builds %s from %s":                                    "Собирается %s из %s",
+	"Builds %s with docker from %s":                        "Сборка docker по %s из %s",
+	"Checking project readiness":                           "Проверка готовности проекта",
+	"Artifact Registry repository":                         "Репозиторий Artifact Registry",
+	"Upload source":                                        "Загрузка исходников",
+	"Submit build":                                         "Запуск сборки",
+	"Build (Cloud Build + Buildpacks)":                     "Сборка (Cloud Build + Buildpacks)",
+	"Build (Cloud Build + Docker)":                         "Сборка (Cloud Build + Docker)",
+	"Deploy to Cloud Run":                                  "Деплой в Cloud Run",
+	"Allow unauthenticated access (IAM)":                   "Публичный доступ (IAM)",
+	"fix: open Cloud Run console to find the service URL.": "исправить: найдите URL сервиса в консоли Cloud Run.",
+	"publish:":    "publish:",
+	"region:  %s": "регион:  %s",
+	"service: %s": "сервис:  %s",
+	"image:   %s": "образ:   %s",
+	"--build-arg %s: the local value goes into the Cloud Build record in plain text": "--build-arg %s: локальное значение попадёт в запись Cloud Build открытым текстом",
+	"builder: docker (%s)": "сборка:  docker (%s)",
+	"source:  %s":          "папка:   %s",
+	"package: %s":          "пакет:   %s",
+	"Revision: %s":         "Ревизия: %s",
+	"URL: %s":              "URL: %s",
+	"URL: (not returned)":  "URL: (не получен)",
+	"link":                 "ссылка",
+
+	// doctor and preflight checks
+	"Suggestions:":                           "Рекомендации:",
+	"All checks passed":                      "Все проверки пройдены",
+	"Authentication":                         "Аутентификация",
+	"Token refresh":                          "Обновление токена",
+	"Credentials file":                       "Файл кредов",
+	"Clock":                                  "Часы",
+	"Network":                                "Сеть",
+	"Config":                                 "Конфиг",
+	"Project access":                         "Доступ к проекту",
+	"Region":                                 "Регион",
+	"Billing":                                "Billing",
+	"Required APIs":                          "Нужные API",
+	"IAM permissions":                        "IAM-права",
+	"Source bucket":                          "Бакет исходников",
+	"Service agents":                         "Служебные агенты",
+	"Go module":                              "Go-модуль",
+	"not authenticated":                      "вход не выполнен",
+	"config not set":                         "конфиг не задан",
+	"no project access":                      "нет доступа к проекту",
+	"project and region not set":             "проект и регион не заданы",
+	"project not set":                        "проект не задан",
+	"region not set":                         "регион не задан",
+	"all enabled":                            "все включены",
+	"all granted":                            "все выданы",
+	"missing: %s":                            "не хватает: %s",
+	"could not verify: %s":                   "не удалось проверить: %s",
+	"not enabled: %s":                        "не включены: %s",
+	"not enabled, publish works without: %s": "не включены, publish без них работает: %s",
+	"could not read billing info":            "не удалось прочитать billing",
+	"could not test permissions":             "не удалось проверить права",
+	"not found: %s":                          "не найден: %s",
+	"%s (legacy, imported on next use)":      "%s (старый путь, будет импортирован при следующем использовании)",
+	"%s is readable by others (mode %s)":     "%s доступен другим пользователям (режим %s)",
+	"could not reach Google to compare clocks":                     "не удалось связаться с Google для сверки часов",
+	"no usable Date header in response":                            "в ответе нет корректного заголовка Date",
+	"skew %s; tokens will be rejected":                             "расхождение %s; токены будут отклонены",
+	"skew %s":                                                      "расхождение %s",
+	"unreachable: %s (%s)":                                         "недоступны: %s (%s)",
+	"%d API hosts reachable over TLS":                              "хосты API доступны по TLS: %d",
+	"Cloud Build and Cloud Run agents have their roles":            "у агентов Cloud Build и Cloud Run есть нужные роли",
+	"no go.mod in %s":                                              "нет go.mod в %s",
+	"%s; builder is docker, but there is no Dockerfile":            "%s; выбран builder docker, но Dockerfile нет",
+	"%s; builds with docker (%s)":                                  "%s; сборка через docker (%s)",
+	"%s; no main package at the root, found: %s":                   "%s; в корне нет пакета main, найдены: %s",
+	"%s; no main package found":                                    "%s; пакет main не найден",
+	"could not test access to gs://%s":                             "не удалось проверить доступ к gs://%s",
+	"no write access to gs://%s":                                   "нет прав на запись в gs://%s",
+	"gs://%s does not exist yet; could not test create permission": "gs://%s ещё не существует; не удалось проверить право на создание",
+	"gs://%s does not exist and you can't create it":               "gs://%s не существует, и у вас нет прав его создать",
+	"gs://%s (created on first publish)":                           "gs://%s (будет создан при первом publish)",
+	"%s (could not verify against available regions)":              "%s (не удалось сверить с доступными регионами)",
+
+	// Fix hints
+	"Check what already finished before re-running, e.g. advncd builds list or advncd status.":                        "Перед повтором проверьте, что уже завершилось, например: advncd builds list или advncd status.",
+	"Check your internet connection and try again.":                                                                   "Проверьте подключение к интернету и повторите.",
+	"Check your internet connection and run publish again.":                                                           "Проверьте подключение к интернету и запустите publish ещё раз.",
+	"Pack the link's content instead: advncd publish --symlinks=follow":                                               "Упаковать содержимое цели вместо ссылки: advncd publish --symlinks=follow",
+	"Or leave such links out: advncd publish --symlinks=skip":                                                         "Или пропускать такие ссылки: advncd publish --symlinks=skip",
+	"Or ignore the path in .advncdignore":                                                                             "Или добавьте путь в .advncdignore",
+	"Fix or remove the dangling link, or use --symlinks=skip":                                                         "Исправьте или удалите битую ссылку либо используйте --symlinks=skip",
+	"Rename the file or ignore it in .advncdignore":                                                                   "Переименуйте файл или добавьте его в .advncdignore",
+	"Wait for editors, generators or builds writing to the directory to finish, then run publish again.":              "Дождитесь, пока редакторы, генераторы или сборки закончат писать в папку, и запустите publish ещё раз.",
+	"Ensure you have storage.objects.get on the Cloud Build bucket.":                                                  "Проверьте, что у вас есть storage.objects.get на бакет Cloud Build.",
+	"Check your internet connection, proxy (HTTPS_PROXY) and firewall.":                                               "Проверьте подключение к интернету, прокси (HTTPS_PROXY) и firewall.",
+	"Check filesystem permissions.":                                                                                   "Проверьте права на файловую систему.",
+	"Upgrade advncd to the latest release.":                                                                           "Обновите advncd до последней версии.",
+	"Unable to resolve user config dir.":                                                                              "Не удалось определить папку конфигурации пользователя.",
+	"Token endpoint returned no access_token.":                                                                        "Эндпоинт токенов не вернул access_token.",
+	"Provide at least one OAuth scope.":                                                                               "Укажите хотя бы один OAuth scope.",
+	"Ensure you are logged in: advncd login":                                                                          "Проверьте, что вход выполнен: advncd login",
+	"Ensure Cloud Run API is enabled and you have permission to deploy.":                                              "Проверьте, что Cloud Run API включён и у вас есть права на деплой.",
+	"Credentials file is corrupted; try 'advncd logout' and login again.":                                             "Файл кредов повреждён; выполните 'advncd logout' и войдите снова.",
+	"Config file is corrupted; re-run: advncd init":                                                                   "Файл конфига повреждён; выполните заново: advncd init",
+	"Run: advncd errors list":                                                                                         "Выполните: advncd errors list",
+	"Example: advncd gcp region set europe-west1":                                                                     "Пример: advncd gcp region set europe-west1",
+	"Link %q: advncd gcp billing link %s":                                                                             "Привязать %q: advncd gcp billing link %s",
+	"Try 'advncd login' again to refresh consent and tokens.":                                                         "Выполните 'advncd login' ещё раз, чтобы обновить согласие и токены.",
+	"Reading the policy needs resourcemanager.projects.getIamPolicy (e.g. roles/iam.securityReviewer).":               "Для чтения политики нужно resourcemanager.projects.getIamPolicy (например, roles/iam.securityReviewer).",
+	"No open billing account is visible to you; ask a billing admin to link one, or to grant you roles/billing.user.": "Вам не виден ни один открытый billing-аккаунт; попросите billing-админа привязать аккаунт или выдать вам roles/billing.user.",
+	"Ensure your OAuth client is type 'Desktop' (installed app).":                                                     "Проверьте, что OAuth-клиент имеет тип 'Desktop' (installed app).",
+	`Example: export ADVNCD_GCP_CLIENT_ID="xxxx.apps.googleusercontent.com"`:                                          `Пример: export ADVNCD_GCP_CLIENT_ID="xxxx.apps.googleusercontent.com"`,
+	"Verify your OAuth client configuration (this flow may not support GCP scopes).":                                  "Проверьте настройки OAuth-клиента (этот flow может не поддерживать scopes GCP).",
+	"Use one of: %s": "Используйте одно из: %s",
+	"Use 6-30 lowercase letters, digits or hyphens; start with a letter, don't end with a hyphen.":     "Используйте 6–30 строчных букв, цифр или дефисов; начинайте с буквы и не заканчивайте дефисом.",
+	"Unable to auto-create the Cloud Build bucket; create it manually in Cloud Storage.":               "Не удалось автоматически создать бакет Cloud Build; создайте его вручную в Cloud Storage.",
+	"Then run: advncd gcp billing link <BILLING_ACCOUNT_ID>":                                           "Затем выполните: advncd gcp billing link <BILLING_ACCOUNT_ID>",
+	"Service was not found after deployment; check Cloud Run console.":                                 "Сервис не найден после деплоя; проверьте консоль Cloud Run.",
+	"Run: advncd publish --name <service>":                                                             "Выполните: advncd publish --name <service>",
+	"Run: advncd logout && advncd login":                                                               "Выполните: advncd logout && advncd login",
+	"Run: advncd login":                                                                                "Выполните: advncd login",
+	"Run: advncd init":                                                                                 "Выполните: advncd init",
+	"Run: advncd apis enable":                                                                          "Выполните: advncd apis enable",
+	"Run: advncd gcp project set <PROJECT_ID>":                                                         "Выполните: advncd gcp project set <PROJECT_ID>",
+	"Run: advncd gcp region set europe-west1":                                                          "Выполните: advncd gcp region set europe-west1",
+	"Run: advncd init --project <PROJECT_ID> --region <REGION>":                                        "Выполните: advncd init --project <PROJECT_ID> --region <REGION>",
+	"Or: advncd gcp project set <PROJECT_ID> && advncd gcp region set <REGION>":                        "Или: advncd gcp project set <PROJECT_ID> && advncd gcp region set <REGION>",
+	"Run: chmod 600 %s":                                                                                "Выполните: chmod 600 %s",
+	"Run: go mod init <module path>":                                                                   "Выполните: go mod init <module path>",
+	"Run advncd publish inside your Go module, or pass its path: advncd publish ./services/api":        "Запускайте advncd publish внутри Go-модуля или передайте путь: advncd publish ./services/api",
+	"Pass the directory of the main package, e.g. advncd publish ./cmd/api":                            "Передайте папку пакета main, например advncd publish ./cmd/api",
+	"Check that go.mod exists there and that `go build` works locally.":                                "Проверьте, что там есть go.mod и что `go build` работает локально.",
+	"Add it to the workspace: go work use %s":                                                          "Добавьте его в workspace: go work use %s",
+	"Or build without the workspace: GOWORK=off advncd publish":                                        "Или соберите без workspace: GOWORK=off advncd publish",
+	"Set GOWORK to an absolute path, or to off.":                                                       "Задайте в GOWORK абсолютный путь или off.",
+	"Run 'advncd logout' and login again.":                                                             "Выполните 'advncd logout' и войдите снова.",
+	"Project creation may still complete; check: advncd gcp project list":                              "Проект ещё может создаться; проверьте: advncd gcp project list",
+	"Project IDs are globally unique (and stay reserved for 30 days after deletion); pick another ID.": "ID проектов глобально уникальны (и остаются занятыми 30 дней после удаления); выберите другой ID.",
+	"Pick a region interactively: advncd init":                                                         "Выберите регион интерактивно: advncd init",
+	"Pass the value with a flag, or run the command in an interactive terminal.":                       "Передайте значение флагом или запустите команду в интерактивном терминале.",
+	"Or set it directly: advncd init --region europe-west1":                                            "Или задайте напрямую: advncd init --region europe-west1",
+	"Or run 'advncd logout' and login again with this version.":                                        "Или выполните 'advncd logout' и войдите снова этой версией.",
+	"Or move the file aside and re-run: advncd init":                                                   "Или переместите файл и выполните заново: advncd init",
+	"Or enable them in GCP Console → APIs & Services → Library":                                        "Или включите их в GCP Console → APIs & Services → Library",
+	"Open the build logs and check buildpack detection / Go entrypoint.":                               "Откройте логи сборки и проверьте определение buildpack / точку входа Go.",
+	"Open the build logs and check the Dockerfile steps.":                                              "Откройте логи сборки и проверьте шаги Dockerfile.",
+	"Or add a Dockerfile to build with docker.":                                                        "Или добавьте Dockerfile, чтобы собрать через docker.",
+	"Pass one: advncd publish --dockerfile <path>":                                                     "Укажите его: advncd publish --dockerfile <path>",
+	"Or build with Buildpacks: advncd publish --builder=buildpacks":                                    "Или соберите через Buildpacks: advncd publish --builder=buildpacks",
+	"--dockerfile, --build-arg and --target need --builder=docker":                                     "--dockerfile, --build-arg и --target работают только с --builder=docker",
+	"Use --build-arg KEY=VALUE, or set KEY in the environment.":                                        "Используйте --build-arg KEY=VALUE или задайте KEY в окружении.",
+	"Pass the path of a Dockerfile.":                                                                   "Укажите путь к Dockerfile.",
+	"The Dockerfile must be inside %s, the directory uploaded.":                                        "Dockerfile должен лежать внутри %s — папки, которая загружается.",
+	"Open Cloud Run console to check deployment status.":                                               "Откройте консоль Cloud Run и проверьте статус деплоя.",
+	"No refresh_token found. Run 'advncd login' again.":                                                "refresh_token не найден. Выполните 'advncd login' снова.",
+	"Move the file aside and re-run: advncd init":                                                      "Переместите файл и выполните заново: advncd init",
+	"Most APIs also require billing to be enabled for the project.":                                    "Большинству API также нужен включённый billing в проекте.",
+	"Listing APIs needs serviceusage.services.list (roles/serviceusage.serviceUsageViewer).":           "Для списка API нужно serviceusage.services.list (roles/serviceusage.serviceUsageViewer).",
+	"Linking needs billing.resourceAssociations.create on the account (roles/billing.user) and resourcemanager.projects.createBillingAssignment on the project.": "Для привязки нужны billing.resourceAssociations.create на аккаунте (roles/billing.user) и resourcemanager.projects.createBillingAssignment на проекте.",
+	"Internal error: missing redirect_uri or code_verifier.":                                                                    "Внутренняя ошибка: нет redirect_uri или code_verifier.",
+	"If your org forbids public access, use authenticated access instead.":                                                      "Если организация запрещает публичный доступ, используйте доступ с аутентификацией.",
+	"If Google requires a secret for this client, export ADVNCD_GCP_CLIENT_SECRET and retry.":                                   "Если Google требует секрет для этого клиента, задайте ADVNCD_GCP_CLIENT_SECRET и повторите.",
+	"Google returned no state for this service.":                                                                                "Google не вернул состояние этого сервиса.",
+	"Google returned no projectNumber.":                                                                                         "Google не вернул projectNumber.",
+	"Google returned an unexpected response; try again or check your OAuth client configuration.":                               "Google вернул неожиданный ответ; повторите или проверьте настройки OAuth-клиента.",
+	"For now (dev), export ADVNCD_GCP_CLIENT_ID from your OAuth Desktop Client ID.":                                             "Пока (dev) задайте ADVNCD_GCP_CLIENT_ID из вашего OAuth Desktop Client ID.",
+	"Ensure your app listens on $PORT (Cloud Run requirement).":                                                                 "Проверьте, что приложение слушает $PORT (требование Cloud Run).",
+	"Ensure your account has permission to list projects.":                                                                      "Проверьте, что у аккаунта есть право на просмотр проектов.",
+	"Ensure you have resourcemanager.projects.get on this project.":                                                             "Проверьте, что у вас есть resourcemanager.projects.get на этот проект.",
+	"Ensure you have resourcemanager.projects.create on the parent folder/organization (roles/resourcemanager.projectCreator).": "Проверьте, что у вас есть resourcemanager.projects.create на родительской папке/организации (roles/resourcemanager.projectCreator).",
+	"Ensure you have permissions to create buckets in this project.":                                                            "Проверьте, что у вас есть права на создание бакетов в этом проекте.",
+	"Ensure you have permission to set IAM policy on Cloud Run service.":                                                        "Проверьте, что у вас есть право менять IAM-политику сервиса Cloud Run.",
+	"Ensure you have permission to create Artifact Registry repositories (roles/artifactregistry.admin or owner in dev).":       "Проверьте, что у вас есть право создавать репозитории Artifact Registry (roles/artifactregistry.admin или owner в dev).",
+	"Ensure you have access to this project.":                                                                                   "Проверьте, что у вас есть доступ к этому проекту.",
+	"Ensure the current directory is readable.":                                                                                 "Проверьте, что текущая папка доступна для чтения.",
+	"Ensure scopes include 'openid email profile'.":                                                                             "Проверьте, что scopes включают 'openid email profile'.",
+	"Ensure Service Usage API is available for this project, and you have permission to view service states.":                   "Проверьте, что Service Usage API доступен в проекте и у вас есть право смотреть состояние сервисов.",
+	"Ensure Cloud Storage API is enabled and you have permission to write objects.":                                             "Проверьте, что Cloud Storage API включён и у вас есть право записывать объекты.",
+	"Ensure Cloud Run API is enabled for this project.":                                                                         "Проверьте, что Cloud Run API включён в этом проекте.",
+	"Ensure Cloud Build API is enabled for this project.":                                                                       "Проверьте, что Cloud Build API включён в этом проекте.",
+	"List recent builds: advncd builds list":                                                                                    "Последние сборки: advncd builds list",
+	"Ensure Cloud Build API is enabled and you have permission to create builds.":                                               "Проверьте, что Cloud Build API включён и у вас есть право запускать сборки.",
+	"Ensure Artifact Registry repo 'advncd' exists in the selected region.":                                                     "Проверьте, что репозиторий Artifact Registry 'advncd' существует в выбранном регионе.",
+	"Ensure Artifact Registry API is enabled for this project.":                                                                 "Проверьте, что Artifact Registry API включён в этом проекте.",
+	"Ensure Artifact Registry API is enabled and you have permission to view repositories.":                                     "Проверьте, что Artifact Registry API включён и у вас есть право смотреть репозитории.",
+	"Enabling APIs needs serviceusage.services.enable (roles/serviceusage.serviceUsageAdmin or owner).":                         "Для включения API нужно serviceusage.services.enable (roles/serviceusage.serviceUsageAdmin или owner).",
+	"Enablement may still complete in the background; re-check with: advncd status":                                             "Включение может завершиться в фоне; проверьте снова: advncd status",
+	"Complete the browser login and consent, then try again.":                                                                   "Завершите вход и согласие в браузере, затем повторите.",
+	"Cloud Build returned no build id (operation metadata missing).":                                                            "Cloud Build не вернул ID сборки (нет метаданных операции).",
+	"Cloud Build and Artifact Registry fail without billing; link a billing account first.":                                     "Cloud Build и Artifact Registry не работают без billing; сначала привяжите billing-аккаунт.",
+	"Check the project quota of your account.":                                                                                  "Проверьте квоту проектов вашего аккаунта.",
+	"Check the filter syntax, e.g. --filter my-app or --filter parent:folders/123":                                              "Проверьте синтаксис фильтра, например --filter my-app или --filter parent:folders/123",
+	"Check if localhost is available and not blocked by firewall.":                                                              "Проверьте, что localhost доступен и не заблокирован firewall.",
+	"Check Cloud Storage permissions or API status.":                                                                            "Проверьте права Cloud Storage или статус API.",
+	"Build is still running; open Cloud Build logs URL to monitor.":                                                             "Сборка ещё идёт; откройте логи Cloud Build, чтобы следить за ней.",
+	"Bucket was created, but upload still failed. Check IAM permissions for Cloud Storage.":                                     "Бакет создан, но загрузка всё равно не удалась. Проверьте IAM-права Cloud Storage.",
+	"Advncd uses Authorization Code + PKCE for GCP; prefer that flow.":                                                          "Для GCP advncd использует Authorization Code + PKCE; используйте этот flow.",
+	"Add a main package (package main with func main) at the module root.":                                                      "Добавьте пакет main (package main с func main) в корень модуля.",
+	"Publish one of them: advncd publish %s":                                                                                    "Опубликуйте один из них: advncd publish %s",
+	"Ask a project owner to grant: %s":                                                                                          "Попросите владельца проекта выдать: %s",
+	"Ask a project owner to grant roles/storage.objectCreator on gs://%s":                                                       "Попросите владельца проекта выдать roles/storage.objectCreator на gs://%s",
+	"Ask a project owner to grant %s, or to create gs://%s":                                                                     "Попросите владельца проекта выдать %s или создать gs://%s",
+	"Grant roles/serviceusage.serviceUsageViewer to check API state.":                                                           "Выдайте roles/serviceusage.serviceUsageViewer, чтобы проверять состояние API.",
+	"Sync your system clock (enable NTP / automatic time).":                                                                     "Синхронизируйте системные часы (включите NTP / автоматическое время).",
+	"Add the translations to internal/i18n (one file per language).":                                                            "Добавьте переводы в internal/i18n (один файл на язык).",
+}
@@ -0,0 +1,281 @@
+package ignore
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Files are the ignore files read in every directory, highest precedence
+// first: a directory uses the first one present and ignores the others
+// (as gcloud does with .gcloudignore over .gitignore). Patterns follow
+// gitignore rules and are relative to the file's directory.
+var Files = []string{".advncdignore", ".gcloudignore", ".gitignore"}
+
+// Always excluded, whatever the ignore files say.
+var builtin = []string{".git/"}
+
+// Defaults come before any ignore file, so "!bin/" re-includes: the CLI
+// binary and bin/ from `go build` are not worth uploading.
+var Defaults = []string{"/advncd", "/bin/"}
+
+// includePrefix pulls another file's patterns in, as in .gcloudignore:
+// "#!include:.gitignore".
+const includePrefix = "#!include:"
+
+type rule struct {
+	base    string // directory of the ignore file, slash-separated, "" at root
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Matcher decides which paths under a root are ignored. Rules are added
+// per directory as the tree is walked; later (deeper) rules win.
+type Matcher struct {
+	root   string
+	always []rule // builtin, checked before and regardless of rules
+	rules  []rule
+	// Sources lists the ignore files read so far, relative to root.
+	Sources []string
+}
+
+// New returns a matcher for root with Defaults and root's ignore file
+// loaded.
+func New(root string) (*Matcher, error) {
+	m := &Matcher{root: root}
+	for _, p := range builtin {
+		if r, ok := parse(p); ok {
+			m.always = append(m.always, r)
+		}
+	}
+	m.add("", Defaults)
+	if err := m.load(""); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// load reads the ignore file of dir (relative to root), if any.
+func (m *Matcher) load(dir string) error {
+	for _, name := range Files {
+		rel := pathJoin(dir, name)
+		lines, err := readLines(filepath.Join(m.root, filepath.FromSlash(rel)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		var patterns []string
+		for _, l := range lines {
+			inc, ok := strings.CutPrefix(l, includePrefix)
+			if !ok {
+				patterns = append(patterns, l)
+				continue
+			}
+			more, err := readLines(filepath.Join(m.root, filepath.FromSlash(pathJoin(dir, strings.TrimSpace(inc)))))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			patterns = append(patterns, more...)
+		}
+		m.add(dir, patterns)
+		m.Sources = append(m.Sources, rel)
+		return nil
+	}
+	return nil
+}
+
+func (m *Matcher) add(base string, patterns []string) {
+	for _, p := range patterns {
+		if r, ok := parse(p); ok {
+			r.base = base
+			m.rules = append(m.rules, r)
+		}
+	}
+}
+
+// Match reports whether rel (slash-separated, relative to root) is
+// ignored. Only rules from rel's ancestors apply; the last match wins.
+// A path inside an ignored directory is not re-checked here: Walk never
+// descends into one, as git never re-includes files of an excluded
+// directory.
+func (m *Matcher) Match(rel string, isDir bool) bool {
+	for _, r := range m.always {
+		if (isDir || !r.dirOnly) && r.re.MatchString(rel) {
+			return true
+		}
+	}
+	ignored := false
+	for _, r := range m.rules {
+		sub := rel
+		if r.base != "" {
+			var ok bool
+			if sub, ok = strings.CutPrefix(rel, r.base+"/"); !ok {
+				continue
+			}
+		}
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if r.re.MatchString(sub) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// Walk calls fn for every path under root that is not ignored, in lexical
+// order, reading nested ignore files on the way down. rel is
+// slash-separated; info is from Lstat. Ignored directories are skipped
+// whole.
+func Walk(root string, fn func(path, rel string, info fs.FileInfo) error) error {
+	m, err := New(root)
+	if err != nil {
+		return err
+	}
+	return m.Walk(fn)
+}
+
+// Walk is the package-level Walk with an existing matcher, whose Sources
+// are complete when it returns.
+func (m *Matcher) Walk(fn func(path, rel string, info fs.FileInfo) error) error {
+	return filepath.Walk(m.root, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(m.root, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if m.Match(rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			if err := m.load(rel); err != nil {
+				return err
+			}
+		}
+		return fn(path, rel, info)
+	})
+}
+
+// parse compiles one gitignore line. Blank lines and comments give ok=false.
+func parse(line string) (rule, bool) {
+	line = trimTrailingSpace(strings.TrimSuffix(line, "\r"))
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule{}, false
+	}
+	var r rule
+	if strings.HasPrefix(line, "!") {
+		r.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return rule{}, false
+	}
+	// A slash at the start or in the middle anchors the pattern to the
+	// ignore file's directory; otherwise it matches at any depth.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := globToRegexp(line)
+	if !anchored {
+		expr = "(?:.*/)?" + expr
+	}
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return rule{}, false
+	}
+	r.re = re
+	return r, true
+}
+
+// globToRegexp translates gitignore glob syntax: * and ? stop at '/',
+// "**/" at the start and "/**/" match any number of directories, and a
+// trailing "/**" matches everything inside.
+func globToRegexp(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case strings.HasPrefix(p[i:], "**/") && (i == 0 || p[i-1] == '/'):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**") && i+2 == len(p) && i > 0 && p[i-1] == '/':
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(p[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := p[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(p):
+			i++
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+	return b.String()
+}
+
+// trimTrailingSpace drops trailing spaces unless escaped with a backslash.
+func trimTrailingSpace(s string) string {
+	for strings.HasSuffix(s, " ") && !strings.HasSuffix(s, `\ `) {
+		s = s[:len(s)-1]
+	}
+	if strings.HasSuffix(s, `\ `) {
+		s = s[:len(s)-2] + " "
+	}
+	return s
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var lines []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	return lines, sc.Err()
+}
+
+func pathJoin(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
@@ -0,0 +1,209 @@
+package ignore
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeTree creates files (slash-separated path -> content) under a temp
+// directory and returns it.
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for rel, data := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestMatch(t *testing.T) {
+	type check struct {
+		rel   string
+		isDir bool
+		want  bool
+	}
+	cases := []struct {
+		name     string
+		patterns string
+		checks   []check
+	}{
+		{"unanchored name matches at any depth", "*.log\n", []check{
+			{"a.log", false, true},
+			{"x/y/b.log", false, true},
+			{"a.logs", false, false},
+		}},
+		{"leading slash anchors to the root", "/build\n", []check{
+			{"build", true, true},
+			{"src/build", true, false},
+		}},
+		{"middle slash anchors too", "docs/*.md\n", []check{
+			{"docs/a.md", false, true},
+			{"x/docs/a.md", false, false},
+			{"docs/sub/a.md", false, false},
+		}},
+		{"trailing slash matches directories only", "tmp/\n", []check{
+			{"tmp", true, true},
+			{"tmp", false, false},
+			{"a/tmp", true, true},
+		}},
+		{"leading **/ matches any depth", "**/cache\n", []check{
+			{"cache", true, true},
+			{"a/b/cache", true, true},
+		}},
+		{"middle /**/ matches zero or more directories", "a/**/z\n", []check{
+			{"a/z", false, true},
+			{"a/b/c/z", false, true},
+			{"b/a/z", false, false},
+		}},
+		{"trailing /** matches everything inside", "out/**\n", []check{
+			{"out/a", false, true},
+			{"out/a/b", false, true},
+			{"out", true, false},
+		}},
+		{"single star stops at a slash", "/a*z\n", []check{
+			{"abcz", false, true},
+			{"ab/cz", false, false},
+		}},
+		{"negation re-includes", "*.env\n!example.env\n", []check{
+			{"prod.env", false, true},
+			{"example.env", false, false},
+		}},
+		{"last match wins", "!keep.txt\n*.txt\n", []check{
+			{"keep.txt", false, true},
+		}},
+		{"last match wins after re-ignoring", "*.txt\n!*.txt\nsecret.txt\n", []check{
+			{"a.txt", false, false},
+			{"secret.txt", false, true},
+		}},
+		{"defaults apply and can be negated", "!/bin/\n", []check{
+			{"bin", true, false},
+			{"advncd", false, true},
+		}},
+		{"comments, blanks and escapes", "# note\n\n\\#hash\n\\!bang\n", []check{
+			{"# note", false, false},
+			{"#hash", false, true},
+			{"!bang", false, true},
+		}},
+		{".git is always ignored", "!.git/\n", []check{
+			{".git", true, true},
+			{"sub/.git", true, true},
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m, err := New(writeTree(t, map[string]string{".advncdignore": c.patterns}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, ch := range c.checks {
+				if got := m.Match(ch.rel, ch.isDir); got != ch.want {
+					t.Errorf("Match(%q, dir=%v) = %v, want %v", ch.rel, ch.isDir, got, ch.want)
+				}
+			}
+		})
+	}
+}
+
+func walked(t *testing.T, root string) ([]string, *Matcher) {
+	t.Helper()
+	m, err := New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	err = m.Walk(func(path, rel string, info fs.FileInfo) error {
+		if !info.IsDir() {
+			got = append(got, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got, m
+}
+
+func TestWalk(t *testing.T) {
+	cases := []struct {
+		name    string
+		files   map[string]string
+		want    []string
+		sources []string
+	}{
+		{
+			name: "nested ignore files apply below their directory",
+			files: map[string]string{
+				".gitignore":        "*.tmp\n",
+				"a.tmp":             "",
+				"main.go":           "",
+				"svc/.gitignore":    "/gen/\n!keep.tmp\n",
+				"svc/keep.tmp":      "",
+				"svc/drop.tmp":      "",
+				"svc/gen/x.go":      "",
+				"svc/sub/gen/y.go":  "",
+				"other/gen/z.go":    "",
+				"other/keep.tmp":    "",
+				"svc/sub/notes.txt": "",
+			},
+			want: []string{
+				".gitignore", "main.go",
+				"other/gen/z.go",
+				"svc/.gitignore", "svc/keep.tmp", "svc/sub/gen/y.go", "svc/sub/notes.txt",
+			},
+			sources: []string{".gitignore", "svc/.gitignore"},
+		},
+		{
+			name: "first ignore file of a directory wins",
+			files: map[string]string{
+				".advncdignore": "*.md\n",
+				".gitignore":    "*.go\n",
+				"a.go":          "",
+				"a.md":          "",
+			},
+			want:    []string{".advncdignore", ".gitignore", "a.go"},
+			sources: []string{".advncdignore"},
+		},
+		{
+			name: "include pulls in another file",
+			files: map[string]string{
+				".gcloudignore": "#!include:.gitignore\n!b.log\n",
+				".gitignore":    "*.log\n",
+				"a.log":         "",
+				"b.log":         "",
+			},
+			want:    []string{".gcloudignore", ".gitignore", "b.log"},
+			sources: []string{".gcloudignore"},
+		},
+		{
+			name: "files of an ignored directory are not re-included",
+			files: map[string]string{
+				".advncdignore":  "vendor/\n!vendor/keep.go\n",
+				"vendor/keep.go": "",
+				"x.go":           "",
+			},
+			want:    []string{".advncdignore", "x.go"},
+			sources: []string{".advncdignore"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, m := walked(t, writeTree(t, c.files))
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("walked:\n  %s\nwant:\n  %s", strings.Join(got, "\n  "), strings.Join(c.want, "\n  "))
+			}
+			if !reflect.DeepEqual(m.Sources, c.sources) {
+				t.Errorf("Sources = %v, want %v", m.Sources, c.sources)
+			}
+		})
+	}
+}
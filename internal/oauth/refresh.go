@@ -50,6 +50,7 @@ func RefreshAccessToken(ctx context.Context, clientID, clientSecret, refreshToke
 		_ = json.Unmarshal(body, &te)
 
 		ae := apperr.New(apperr.AuthTokenExchange).
+			WithCause(apperr.HTTP(res.StatusCode, body)).
 			WithMeta("http_status", res.Status).
 			WithMeta("oauth_error", te.Error).
 			WithMeta("oauth_error_description", te.ErrorDescription)
@@ -71,6 +71,7 @@ func ExchangeAuthCode(ctx context.Context, clientID, clientSecret, code, redirec
 		_ = json.Unmarshal(body, &te)
 
 		ae := apperr.New(apperr.AuthTokenExchange).
+			WithCause(apperr.HTTP(res.StatusCode, body)).
 			WithMeta("http_status", res.Status).
 			WithMeta("oauth_error", te.Error).
 			WithMeta("oauth_error_description", te.ErrorDescription)
@@ -38,6 +38,7 @@ func FetchUserInfo(ctx context.Context, accessToken string) (*UserInfo, error) {
 	body, _ := io.ReadAll(res.Body)
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		return nil, apperr.New(apperr.AuthUserInfo).
+			WithCause(apperr.HTTP(res.StatusCode, body)).
 			WithMeta("http_status", res.Status).
 			WithMeta("raw_body", string(body)).
 			WithFix("Ensure scopes include 'openid email profile'.")
@@ -0,0 +1,132 @@
+package preflight
+
+import (
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var (
+	ErrBlocked = apperr.E("C-PUBLISH-001", "Publish blocked by preflight checks",
+		apperr.WithSummary("A preflight check failed, so nothing was uploaded or deployed."))
+	ErrNotGoModule = apperr.E("C-PUBLISH-002", "Not a Go module",
+		apperr.WithSummary("publish builds a package of a Go module, and there is no go.mod in its directory or above it."))
+	ErrServiceName = apperr.E("C-PUBLISH-003", "Unable to determine service name",
+		apperr.WithSummary("The folder name doesn't yield a valid Cloud Run service name."))
+	ErrNoDockerfile = apperr.E("C-PUBLISH-005", "No Dockerfile to build",
+		apperr.WithSummary("The docker builder was chosen, but there is no Dockerfile in the app directory or above it."))
+	ErrUnhealthy = apperr.E("B-DOCTOR-001", "Environment checks failed",
+		apperr.WithSummary("One or more doctor checks failed; see the table for details."))
+)
+
+type Status string
+
+const (
+	Pass Status = "pass"
+	Warn Status = "warn" // couldn't verify, or works but not ideal; never blocks
+	Fail Status = "fail" // the operation would fail; blocks
+	Skip Status = "skip" // a prerequisite failed
+)
+
+// Check is the outcome of one readiness check.
+type Check struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Status Status   `json:"status"`
+	Detail string   `json:"detail,omitempty"`
+	Fixes  []string `json:"fixes,omitempty"`
+}
+
+func pass(id, title, detail string) Check {
+	return Check{ID: id, Title: title, Status: Pass, Detail: detail}
+}
+
+func warn(id, title, detail string, fixes ...string) Check {
+	return Check{ID: id, Title: title, Status: Warn, Detail: detail, Fixes: fixes}
+}
+
+func fail(id, title, detail string, fixes ...string) Check {
+	return Check{ID: id, Title: title, Status: Fail, Detail: detail, Fixes: fixes}
+}
+
+func skip(id, title, detail string) Check {
+	return Check{ID: id, Title: title, Status: Skip, Detail: detail}
+}
+
+// fromError turns an apperr into a failed/warned check, keeping its fixes.
+func fromError(status Status, id, title string, err error) Check {
+	c := Check{ID: id, Title: title, Status: status, Detail: err.Error()}
+	if ae, ok := apperr.As(err); ok {
+		c.Detail = ae.Message
+		c.Fixes = append(c.Fixes, ae.FixWith...)
+	}
+	return c
+}
+
+// Report is an ordered list of checks.
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+func (r *Report) add(c Check) {
+	r.Checks = append(r.Checks, c)
+}
+
+// Blocked reports whether any check failed.
+func (r *Report) Blocked() bool {
+	for _, c := range r.Checks {
+		if c.Status == Fail {
+			return true
+		}
+	}
+	return false
+}
+
+// Fixes returns the fixes of failed checks, de-duplicated, in check order.
+func (r *Report) Fixes() []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, c := range r.Checks {
+		if c.Status != Fail {
+			continue
+		}
+		for _, f := range c.Fixes {
+			if !seen[f] {
+				seen[f] = true
+				out = append(out, f)
+			}
+		}
+	}
+	return out
+}
+
+// Err returns nil if nothing failed, otherwise one ErrBlocked error listing
+// every failed check in Meta and all their fixes.
+func (r *Report) Err() error {
+	return r.ErrAs(ErrBlocked)
+}
+
+// ErrAs is Err with a caller-chosen catalog entry.
+func (r *Report) ErrAs(entry apperr.Entry) error {
+	if !r.Blocked() {
+		return nil
+	}
+	ae := apperr.New(entry)
+	for _, c := range r.Checks {
+		if c.Status == Fail {
+			ae = ae.WithMeta(c.ID, c.Detail)
+		}
+	}
+	for _, f := range r.Fixes() {
+		ae = ae.WithFix(f)
+	}
+	return ae
+}
+
+// Find returns the check with the given ID.
+func (r *Report) Find(id string) *Check {
+	for i := range r.Checks {
+		if r.Checks[i].ID == id {
+			return &r.Checks[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,446 @@
+package preflight
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/cloudbuild"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/creds"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpcrm"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gomod"
+)
+
+// Doctor-only check IDs.
+const (
+	IDCredentials   = "credentials"
+	IDTokenRefresh  = "token_refresh"
+	IDClock         = "clock"
+	IDNetwork       = "network"
+	IDServiceAgents = "service_agents"
+	IDGoModule      = "go_module"
+)
+
+// APIHosts are the Google endpoints advncd talks to.
+var APIHosts = []string{
+	"oauth2.googleapis.com",
+	"cloudresourcemanager.googleapis.com",
+	"serviceusage.googleapis.com",
+	"cloudbilling.googleapis.com",
+	"run.googleapis.com",
+	"cloudbuild.googleapis.com",
+	"artifactregistry.googleapis.com",
+	"storage.googleapis.com",
+}
+
+// Clock skew thresholds. OAuth and TLS start failing around five minutes.
+const (
+	clockWarnSkew = 30 * time.Second
+	clockFailSkew = 5 * time.Minute
+)
+
+type DoctorInput struct {
+	Dir  string // the path publish would build
+	Repo string // Artifact Registry repository ID
+}
+
+// Doctor runs every check publish runs plus local environment checks.
+// Unlike Publish it never stops early: local checks run regardless.
+func Doctor(ctx context.Context, in DoctorInput) *Report {
+	r := &Report{}
+
+	r.add(CheckCredsFile())
+	tb, c := CheckTokenRefresh(ctx)
+	r.add(c)
+
+	var clock, network Check
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); clock = CheckClock(ctx) }()
+	go func() { defer wg.Done(); network = CheckNetwork(ctx, APIHosts) }()
+	wg.Wait()
+	r.add(clock)
+	r.add(network)
+
+	cfg, c := CheckConfigFile()
+	r.add(c)
+
+	cloud := []struct{ id, title string }{
+		{IDProject, "Project access"},
+		{IDRegion, "Region"},
+		{IDBilling, "Billing"},
+		{IDAPIs, "Required APIs"},
+		{IDIAM, "IAM permissions"},
+		{IDBucket, "Source bucket"},
+		{IDServiceAgents, "Service agents"},
+	}
+	skipCloud := func(from int, reason string) {
+		for _, s := range cloud[from:] {
+			r.add(skip(s.id, s.title, reason))
+		}
+	}
+
+	switch {
+	case tb == nil:
+		skipCloud(0, "not authenticated")
+	case c.Status == Fail:
+		skipCloud(0, "config not set")
+	default:
+		proj, pc := CheckProject(ctx, tb.AccessToken, cfg.ProjectID)
+		r.add(pc)
+		if proj == nil {
+			skipCloud(1, "no project access")
+			break
+		}
+		checks := []func() Check{
+			func() Check { return CheckRegion(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region) },
+			func() Check { return CheckBilling(ctx, tb.AccessToken, cfg.ProjectID) },
+			func() Check { return CheckAPIs(ctx, tb.AccessToken, proj.ProjectNumber) },
+			func() Check { return CheckIAM(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region, in.Repo) },
+			func() Check { return CheckBucket(ctx, tb.AccessToken, cfg.ProjectID) },
+			func() Check { return CheckServiceAgents(ctx, tb.AccessToken, cfg.ProjectID, proj.ProjectNumber) },
+		}
+		for _, c := range runAll(checks) {
+			r.add(c)
+		}
+	}
+
+	builder := ""
+	if cfg != nil {
+		builder = cfg.Builder
+	}
+	r.add(CheckGoModule(in.Dir, builder))
+	return r
+}
+
+// runAll runs independent checks concurrently, keeping their order.
+func runAll(checks []func() Check) []Check {
+	out := make([]Check, len(checks))
+	var wg sync.WaitGroup
+	for i, f := range checks {
+		wg.Add(1)
+		go func(i int, f func() Check) {
+			defer wg.Done()
+			out[i] = f()
+		}(i, f)
+	}
+	wg.Wait()
+	return out
+}
+
+// CheckCredsFile verifies the credentials file exists and is private.
+func CheckCredsFile() Check {
+	const title = "Credentials file"
+	store, err := creds.DefaultStore()
+	if err != nil {
+		return fromError(Fail, IDCredentials, title, err)
+	}
+	fi, err := os.Stat(store.Path)
+	if os.IsNotExist(err) {
+		if store.LegacyPath != "" {
+			if _, err := os.Stat(store.LegacyPath); err == nil {
+				return pass(IDCredentials, title, store.LegacyPath+" (legacy, imported on next use)")
+			}
+		}
+		return fail(IDCredentials, title, "not found: "+store.Path, "Run: advncd login")
+	}
+	if err != nil {
+		return fail(IDCredentials, title, err.Error())
+	}
+	// Windows has no meaningful Unix mode bits.
+	if runtime.GOOS != "windows" && fi.Mode().Perm()&0o077 != 0 {
+		return warn(IDCredentials, title,
+			fmt.Sprintf("%s is readable by others (mode %04o)", store.Path, fi.Mode().Perm()),
+			"Run: chmod 600 "+store.Path)
+	}
+	return pass(IDCredentials, title, store.Path)
+}
+
+// CheckTokenRefresh exchanges the refresh token to prove the grant is alive.
+func CheckTokenRefresh(ctx context.Context) (*auth.TokenBundle, Check) {
+	tb, err := auth.RefreshAccessToken(ctx)
+	return checkToken(IDTokenRefresh, "Token refresh", tb, err)
+}
+
+// CheckClock compares the local clock with Google's Date header.
+func CheckClock(ctx context.Context) Check {
+	const title = "Clock"
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, "https://oauth2.googleapis.com/", nil)
+	if err != nil {
+		return warn(IDClock, title, err.Error())
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	start := time.Now()
+	res, err := client.Do(req)
+	if err != nil {
+		return warn(IDClock, title, "could not reach Google to compare clocks")
+	}
+	res.Body.Close()
+	rtt := time.Since(start)
+
+	remote, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		return warn(IDClock, title, "no usable Date header in response")
+	}
+	// Date has one-second resolution; compare against the request midpoint.
+	skew := remote.Sub(start.Add(rtt / 2)).Round(time.Second)
+	abs := skew
+	if abs < 0 {
+		abs = -abs
+	}
+	detail := fmt.Sprintf("skew %s", skew)
+	switch {
+	case abs >= clockFailSkew:
+		return fail(IDClock, title, detail+"; tokens will be rejected",
+			"Sync your system clock (enable NTP / automatic time).")
+	case abs >= clockWarnSkew:
+		return warn(IDClock, title, detail,
+			"Sync your system clock (enable NTP / automatic time).")
+	}
+	return pass(IDClock, title, detail)
+}
+
+// CheckNetwork opens a TLS connection to each host concurrently.
+func CheckNetwork(ctx context.Context, hosts []string) Check {
+	const title = "Network"
+	failed := make([]string, len(hosts))
+	var wg sync.WaitGroup
+	for i, h := range hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+			d := &tls.Dialer{NetDialer: &net.Dialer{Timeout: 5 * time.Second}}
+			dctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+			conn, err := d.DialContext(dctx, "tcp", net.JoinHostPort(host, "443"))
+			if err != nil {
+				failed[i] = err.Error()
+				return
+			}
+			conn.Close()
+		}(i, h)
+	}
+	wg.Wait()
+
+	var bad []string
+	firstErr := ""
+	for i, f := range failed {
+		if f != "" {
+			bad = append(bad, hosts[i])
+			if firstErr == "" {
+				firstErr = f
+			}
+		}
+	}
+	if len(bad) > 0 {
+		return fail(IDNetwork, title, "unreachable: "+strings.Join(bad, ", ")+" ("+firstErr+")",
+			"Check your internet connection, proxy (HTTPS_PROXY) and firewall.")
+	}
+	return pass(IDNetwork, title, fmt.Sprintf("%d API hosts reachable over TLS", len(hosts)))
+}
+
+// CheckConfigFile loads the config and validates it.
+func CheckConfigFile() (*config.Config, Check) {
+	store, err := config.DefaultStore()
+	if err != nil {
+		return nil, fromError(Fail, IDConfig, "Config", err)
+	}
+	cfg, err := store.Load()
+	if err != nil {
+		return nil, fromError(Fail, IDConfig, "Config", err)
+	}
+	return cfg, CheckConfig(cfg)
+}
+
+// serviceAgent is a Google-managed account that must keep its role.
+type serviceAgent struct {
+	name   string
+	member string
+	roles  []string // any one of these is enough
+}
+
+// CheckServiceAgents verifies the accounts Cloud Build and Cloud Run act as
+// still hold their roles. Agents appear when the API is first enabled.
+func CheckServiceAgents(ctx context.Context, accessToken, projectID, projectNumber string) Check {
+	const title = "Service agents"
+	policy, err := gcpcrm.GetIamPolicy(ctx, accessToken, projectID)
+	if err != nil {
+		return fromError(Warn, IDServiceAgents, title, err)
+	}
+
+	agents := []serviceAgent{
+		{"Cloud Build service agent", "serviceAccount:service-" + projectNumber + "@gcp-sa-cloudbuild.iam.gserviceaccount.com",
+			[]string{"roles/cloudbuild.serviceAgent"}},
+		{"Cloud Run service agent", "serviceAccount:service-" + projectNumber + "@serverless-robot-prod.iam.gserviceaccount.com",
+			[]string{"roles/run.serviceAgent"}},
+	}
+
+	var problems, fixes []string
+	for _, a := range agents {
+		if !hasAnyRole(policy, a.member, a.roles) {
+			problems = append(problems, a.name+" lacks "+a.roles[0])
+			fixes = append(fixes, grantCommand(projectID, a.member, a.roles[0]))
+		}
+	}
+
+	// Builds run as the legacy Cloud Build account or, on newer projects,
+	// the Compute Engine default account; either one needs to be able to build.
+	builders := []string{
+		"serviceAccount:" + projectNumber + "@cloudbuild.gserviceaccount.com",
+		"serviceAccount:" + projectNumber + "-compute@developer.gserviceaccount.com",
+	}
+	builderRoles := []string{"roles/cloudbuild.builds.builder", "roles/editor", "roles/owner"}
+	ok := false
+	for _, b := range builders {
+		if hasAnyRole(policy, b, builderRoles) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		problems = append(problems, "no build service account holds roles/cloudbuild.builds.builder")
+		fixes = append(fixes, grantCommand(projectID, builders[0], builderRoles[0]))
+	}
+
+	if len(problems) > 0 {
+		return fail(IDServiceAgents, title, strings.Join(problems, "; "), fixes...)
+	}
+	return pass(IDServiceAgents, title, "Cloud Build and Cloud Run agents have their roles")
+}
+
+func hasAnyRole(p *gcpcrm.Policy, member string, roles []string) bool {
+	for _, have := range p.RolesOf(member) {
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func grantCommand(projectID, member, role string) string {
+	return fmt.Sprintf("Run: gcloud projects add-iam-policy-binding %s --member=%s --role=%s", projectID, member, role)
+}
+
+// CheckGoModule inspects dir the way publish will: the module layout
+// gomod.Resolve finds, the builder publish picks (builder is the
+// configured one, "" to choose by the Dockerfile) and what it needs.
+func CheckGoModule(dir, builder string) Check {
+	const title = "Go module"
+	layout, err := gomod.Resolve(dir)
+	noModule := errors.Is(err, gomod.ErrNoModule)
+	if noModule {
+		abs, aerr := filepath.Abs(dir)
+		if aerr != nil {
+			return fail(IDGoModule, title, aerr.Error())
+		}
+		layout, err = &gomod.Layout{Root: abs, Module: abs, Package: abs}, nil
+	}
+	if err != nil {
+		var ae *apperr.Error
+		if errors.As(err, &ae) {
+			return fail(IDGoModule, title, ae.Message, ae.FixWith...)
+		}
+		return fail(IDGoModule, title, err.Error())
+	}
+
+	dockerfile := layout.Nearest("Dockerfile")
+	if builder == "" {
+		builder = cloudbuild.BuilderBuildpacks
+		if dockerfile != "" {
+			builder = cloudbuild.BuilderDocker
+		}
+	}
+
+	detail := layout.ModulePath
+	if noModule {
+		detail = fmt.Sprintf("no go.mod in %s", layout.Package)
+	} else if layout.GoVersion != "" {
+		detail += " (go " + layout.GoVersion + ")"
+	}
+
+	if builder == cloudbuild.BuilderDocker {
+		if dockerfile == "" {
+			return fail(IDGoModule, title, fmt.Sprintf("%s; builder is docker, but there is no Dockerfile", detail),
+				"Pass one: advncd publish --dockerfile <path>",
+				"Or build with Buildpacks: advncd publish --builder=buildpacks")
+		}
+		return pass(IDGoModule, title, fmt.Sprintf("%s; builds with docker (%s)", detail, dockerfile))
+	}
+	if noModule {
+		return warn(IDGoModule, title, detail,
+			"Run advncd publish inside your Go module, or pass its path: advncd publish ./services/api",
+			"Or add a Dockerfile to build with docker.")
+	}
+	if hasMainPackage(layout.Package) {
+		return pass(IDGoModule, title, detail)
+	}
+	if mains := mainPackagesUnder(filepath.Join(layout.Package, "cmd")); len(mains) > 0 {
+		return warn(IDGoModule, title, fmt.Sprintf("%s; no main package at the root, found: %s", detail, strings.Join(mains, ", ")),
+			"Publish one of them: advncd publish "+mains[0])
+	}
+	return fail(IDGoModule, title, fmt.Sprintf("%s; no main package found", detail),
+		"Add a main package (package main with func main) at the module root.",
+		"Or add a Dockerfile to build with docker.")
+}
+
+func hasMainPackage(dir string) bool {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		if packageName(f) == "main" {
+			return true
+		}
+	}
+	return false
+}
+
+// mainPackagesUnder returns ./cmd/<name> style paths holding a main package.
+func mainPackagesUnder(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var out []string
+	for _, e := range entries {
+		if e.IsDir() && hasMainPackage(filepath.Join(dir, e.Name())) {
+			out = append(out, "./cmd/"+e.Name())
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// packageName returns the package clause of a Go file, or "".
+func packageName(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) >= 2 && fields[0] == "package" {
+			return fields[1]
+		}
+	}
+	return ""
+}
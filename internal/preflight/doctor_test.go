@@ -0,0 +1,123 @@
+package preflight
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for rel, data := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestCheckGoModule(t *testing.T) {
+	t.Setenv("GOWORK", "")
+	const main = "package main\n\nfunc main() {}\n"
+	cases := []struct {
+		name    string
+		files   map[string]string
+		dir     string
+		builder string
+		status  Status
+		detail  string
+	}{
+		{
+			name:   "main package at the module root",
+			files:  map[string]string{"go.mod": "module example.com/app\n\ngo 1.22 // comment\n", "main.go": main},
+			status: Pass,
+			detail: "example.com/app (go 1.22)",
+		},
+		{
+			name:   "package in a subdirectory of the module",
+			files:  map[string]string{"go.mod": "module example.com/app\n", "cmd/api/main.go": main},
+			dir:    "cmd/api",
+			status: Pass,
+			detail: "example.com/app",
+		},
+		{
+			name: "workspace module",
+			files: map[string]string{
+				"go.work":     "go 1.22\n\nuse (\n\t./svc\n\t./lib\n)\n",
+				"svc/go.mod":  "module example.com/svc\n\ngo 1.22\n\nrequire example.com/lib v0.0.0\n",
+				"svc/main.go": main,
+				"lib/go.mod":  "module example.com/lib\n",
+			},
+			dir:    "svc",
+			status: Pass,
+			detail: "example.com/svc (go 1.22)",
+		},
+		{
+			name:   "module outside its workspace",
+			files:  map[string]string{"go.work": "go 1.22\n\nuse ./lib\n", "lib/go.mod": "module example.com/lib\n", "svc/go.mod": "module example.com/svc\n", "svc/main.go": main},
+			dir:    "svc",
+			status: Fail,
+			detail: "Unable to read the Go module layout",
+		},
+		{
+			name:   "main packages under cmd only",
+			files:  map[string]string{"go.mod": "module example.com/app\n", "cmd/api/main.go": main},
+			status: Warn,
+			detail: "example.com/app; no main package at the root, found: ./cmd/api",
+		},
+		{
+			name:   "no main package",
+			files:  map[string]string{"go.mod": "module example.com/app\n", "lib.go": "package app\n"},
+			status: Fail,
+			detail: "example.com/app; no main package found",
+		},
+		{
+			name:   "no main package, but a Dockerfile",
+			files:  map[string]string{"go.mod": "module example.com/app\n", "Dockerfile": "FROM scratch\n", "svc/lib.go": "package svc\n"},
+			dir:    "svc",
+			status: Pass,
+			detail: "example.com/app; builds with docker (Dockerfile)",
+		},
+		{
+			name:    "buildpacks configured over a Dockerfile",
+			files:   map[string]string{"go.mod": "module example.com/app\n", "Dockerfile": "FROM scratch\n", "lib.go": "package app\n"},
+			builder: "buildpacks",
+			status:  Fail,
+			detail:  "example.com/app; no main package found",
+		},
+		{
+			name:    "docker configured without a Dockerfile",
+			files:   map[string]string{"go.mod": "module example.com/app\n", "main.go": main},
+			builder: "docker",
+			status:  Fail,
+			detail:  "example.com/app; builder is docker, but there is no Dockerfile",
+		},
+		{
+			name:   "no go.mod",
+			files:  map[string]string{"main.go": main},
+			status: Warn,
+			detail: "no go.mod in ",
+		},
+		{
+			name:   "no go.mod, but a Dockerfile",
+			files:  map[string]string{"Dockerfile": "FROM scratch\n", "index.html": ""},
+			status: Pass,
+			detail: "no go.mod in ",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			root := writeTree(t, c.files)
+			got := CheckGoModule(filepath.Join(root, filepath.FromSlash(c.dir)), c.builder)
+			if got.Status != c.status || !strings.HasPrefix(got.Detail, c.detail) {
+				t.Errorf("got %s %q, want %s %q", got.Status, got.Detail, c.status, c.detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package preflight
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/auth"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/cloudbuild"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/config"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpartifact"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpbilling"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpcrm"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpserviceusage"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcs"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/regions"
+)
+
+// Check IDs, stable for scripts (and --output json later).
+const (
+	IDAuth    = "auth"
+	IDConfig  = "config"
+	IDProject = "project"
+	IDRegion  = "region"
+	IDBilling = "billing"
+	IDAPIs    = "apis"
+	IDIAM     = "iam"
+	IDBucket  = "bucket"
+)
+
+// PublishPermissions are the project-level permissions publish uses, with
+// the narrowest predefined role granting each one.
+var PublishPermissions = map[string]string{
+	"run.services.get":                              "roles/run.admin",
+	"run.services.create":                           "roles/run.admin",
+	"run.services.update":                           "roles/run.admin",
+	"run.services.setIamPolicy":                     "roles/run.admin",
+	"cloudbuild.builds.create":                      "roles/cloudbuild.builds.editor",
+	"cloudbuild.builds.get":                         "roles/cloudbuild.builds.editor",
+	"artifactregistry.repositories.get":             "roles/artifactregistry.writer",
+	"artifactregistry.repositories.uploadArtifacts": "roles/artifactregistry.writer",
+	"storage.objects.create":                        "roles/storage.objectCreator",
+	"iam.serviceAccounts.actAs":                     "roles/iam.serviceAccountUser",
+}
+
+// Needed only when the resource doesn't exist yet and publish creates it.
+const (
+	permRepoCreate   = "artifactregistry.repositories.create"
+	permBucketCreate = "storage.buckets.create"
+)
+
+var createRoles = map[string]string{
+	permRepoCreate:   "roles/artifactregistry.admin",
+	permBucketCreate: "roles/storage.admin",
+}
+
+type PublishInput struct {
+	Config *config.Config
+	Repo   string // Artifact Registry repository ID
+}
+
+type PublishResult struct {
+	Report
+	// Set when the corresponding checks passed; publish reuses them.
+	Token         *auth.TokenBundle
+	ProjectNumber string
+}
+
+// Publish runs every check publish depends on, without mutating anything.
+// Independent cloud checks run concurrently; results keep a fixed order.
+func Publish(ctx context.Context, in PublishInput) *PublishResult {
+	r := &PublishResult{}
+
+	tb, c := CheckAuth(ctx)
+	r.add(c)
+	r.Token = tb
+
+	r.add(CheckConfig(in.Config))
+
+	cloud := []struct{ id, title string }{
+		{IDProject, "Project access"},
+		{IDRegion, "Region"},
+		{IDBilling, "Billing"},
+		{IDAPIs, "Required APIs"},
+		{IDIAM, "IAM permissions"},
+		{IDBucket, "Source bucket"},
+	}
+	skipAll := func(reason string) {
+		for _, s := range cloud {
+			r.add(skip(s.id, s.title, reason))
+		}
+	}
+	if tb == nil {
+		skipAll("not authenticated")
+		return r
+	}
+	if r.Blocked() {
+		skipAll("config not set")
+		return r
+	}
+
+	cfg := in.Config
+	proj, c := CheckProject(ctx, tb.AccessToken, cfg.ProjectID)
+	r.add(c)
+	if proj == nil {
+		for _, s := range cloud[1:] {
+			r.add(skip(s.id, s.title, "no project access"))
+		}
+		return r
+	}
+	r.ProjectNumber = proj.ProjectNumber
+
+	checks := []func() Check{
+		func() Check { return CheckRegion(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region) },
+		func() Check { return CheckBilling(ctx, tb.AccessToken, cfg.ProjectID) },
+		func() Check { return CheckAPIs(ctx, tb.AccessToken, proj.ProjectNumber) },
+		func() Check { return CheckIAM(ctx, tb.AccessToken, cfg.ProjectID, cfg.Region, in.Repo) },
+		func() Check { return CheckBucket(ctx, tb.AccessToken, cfg.ProjectID) },
+	}
+	for _, c := range runAll(checks) {
+		r.add(c)
+	}
+	return r
+}
+
+// CheckAuth verifies we have a usable token with the cloud-platform scope.
+func CheckAuth(ctx context.Context) (*auth.TokenBundle, Check) {
+	const title = "Authentication"
+	tb, err := auth.GetAccessToken(ctx)
+	return checkToken(IDAuth, title, tb, err)
+}
+
+// checkToken turns a token lookup into a check, requiring cloud-platform.
+func checkToken(id, title string, tb *auth.TokenBundle, err error) (*auth.TokenBundle, Check) {
+	if err != nil {
+		return nil, fromError(Fail, id, title, err)
+	}
+	// Scopes were always recorded at login; an empty list means a hand-made
+	// or imported file, which we can't judge.
+	if len(tb.Scopes) > 0 && !tb.HasScope(auth.CloudPlatformScope) {
+		return nil, fromError(Fail, id, title, apperr.New(auth.ErrInsufficientScopes).
+			WithMeta("missing", auth.CloudPlatformScope).
+			WithFix("Run: advncd logout && advncd login"))
+	}
+	return tb, pass(id, title, tb.Email)
+}
+
+// CheckConfig verifies project and region are set.
+func CheckConfig(cfg *config.Config) Check {
+	const title = "Config"
+	switch {
+	case cfg == nil || (cfg.ProjectID == "" && cfg.Region == ""):
+		return fail(IDConfig, title, "project and region not set", "Run: advncd init")
+	case cfg.ProjectID == "":
+		return fail(IDConfig, title, "project not set", "Run: advncd gcp project set <PROJECT_ID>")
+	case cfg.Region == "":
+		return fail(IDConfig, title, "region not set", "Run: advncd gcp region set europe-west1")
+	}
+	return pass(IDConfig, title, cfg.ProjectID+" / "+cfg.Region)
+}
+
+// CheckProject verifies the project exists and is readable.
+func CheckProject(ctx context.Context, accessToken, projectID string) (*gcpcrm.ProjectGet, Check) {
+	const title = "Project access"
+	p, err := gcpcrm.GetProject(ctx, accessToken, projectID)
+	if err != nil {
+		return nil, fromError(Fail, IDProject, title, err)
+	}
+	return p, pass(IDProject, title, projectID+" (#"+p.ProjectNumber+")")
+}
+
+// CheckRegion validates the region against the live Cloud Run list.
+func CheckRegion(ctx context.Context, accessToken, projectID, region string) Check {
+	const title = "Region"
+	available, err := regions.List(ctx, accessToken, projectID)
+	if err != nil {
+		return warn(IDRegion, title, region+" (could not verify against available regions)")
+	}
+	if err := regions.Validate(region, available); err != nil {
+		c := fromError(Fail, IDRegion, title, err)
+		c.Detail = region + " is not available for Cloud Run"
+		return c
+	}
+	return pass(IDRegion, title, region)
+}
+
+// CheckBilling verifies billing is enabled.
+func CheckBilling(ctx context.Context, accessToken, projectID string) Check {
+	const title = "Billing"
+	info, err := gcpbilling.GetBillingInfo(ctx, accessToken, projectID)
+	if err != nil {
+		return warn(IDBilling, title, "could not read billing info")
+	}
+	if !info.BillingEnabled {
+		return fromError(Fail, IDBilling, title, gcpbilling.DisabledError(ctx, accessToken, projectID))
+	}
+	return pass(IDBilling, title, strings.TrimPrefix(info.BillingAccountName, "billingAccounts/"))
+}
+
+// CheckAPIs verifies the APIs publish uses are enabled. Other required
+// APIs (monitoring, for metrics) missing is a warning, not a blocker.
+func CheckAPIs(ctx context.Context, accessToken, projectNumber string) Check {
+	const title = "Required APIs"
+	rd := gcpserviceusage.CheckRequired(ctx, accessToken, projectNumber)
+	if missing := rd.MissingFor("publish"); len(missing) > 0 {
+		c := fromError(Fail, IDAPIs, title, gcpserviceusage.DisabledError(missing))
+		c.Detail = "not enabled: " + strings.Join(missing, ", ")
+		return c
+	}
+	if len(rd.Unknown) > 0 {
+		return warn(IDAPIs, title, "could not verify: "+strings.Join(rd.Unknown, ", "),
+			"Grant roles/serviceusage.serviceUsageViewer to check API state.")
+	}
+	if len(rd.Missing) > 0 {
+		c := fromError(Warn, IDAPIs, title, gcpserviceusage.DisabledError(rd.Missing))
+		c.Detail = "not enabled, publish works without: " + strings.Join(rd.Missing, ", ")
+		return c
+	}
+	return pass(IDAPIs, title, "all enabled")
+}
+
+// CheckIAM verifies the caller holds the permissions publish needs,
+// including create permissions for the repository if it doesn't exist yet.
+func CheckIAM(ctx context.Context, accessToken, projectID, region, repo string) Check {
+	const title = "IAM permissions"
+
+	roles := map[string]string{}
+	for p, r := range PublishPermissions {
+		roles[p] = r
+	}
+	if exists, err := gcpartifact.RepoExists(ctx, accessToken, projectID, region, repo); err == nil && !exists {
+		roles[permRepoCreate] = createRoles[permRepoCreate]
+	}
+
+	perms := make([]string, 0, len(roles))
+	for p := range roles {
+		perms = append(perms, p)
+	}
+	sort.Strings(perms)
+
+	granted, err := gcpcrm.TestIamPermissions(ctx, accessToken, projectID, perms)
+	if err != nil {
+		return warn(IDIAM, title, "could not test permissions")
+	}
+	missing, need := missingPermissions(perms, granted, roles)
+	if len(missing) > 0 {
+		return fail(IDIAM, title, "missing: "+strings.Join(missing, ", "),
+			"Ask a project owner to grant: "+strings.Join(need, ", "))
+	}
+	return pass(IDIAM, title, "all granted")
+}
+
+// CheckBucket verifies sources can be uploaded to the Cloud Build bucket,
+// or that it can be created on first publish.
+func CheckBucket(ctx context.Context, accessToken, projectID string) Check {
+	const title = "Source bucket"
+	bucket := cloudbuild.SourceBucket(projectID)
+
+	granted, exists, err := gcs.TestBucketPermissions(ctx, accessToken, bucket, []string{"storage.objects.create"})
+	if err != nil {
+		return warn(IDBucket, title, "could not test access to gs://"+bucket)
+	}
+	if exists {
+		if len(granted) == 0 {
+			return fail(IDBucket, title, "no write access to gs://"+bucket,
+				"Ask a project owner to grant roles/storage.objectCreator on gs://"+bucket)
+		}
+		return pass(IDBucket, title, "gs://"+bucket)
+	}
+
+	// Created by the first publish; the user needs storage.buckets.create.
+	granted, err = gcpcrm.TestIamPermissions(ctx, accessToken, projectID, []string{permBucketCreate})
+	if err != nil {
+		return warn(IDBucket, title, "gs://"+bucket+" does not exist yet; could not test create permission")
+	}
+	if len(granted) == 0 {
+		return fail(IDBucket, title, "gs://"+bucket+" does not exist and you can't create it",
+			"Ask a project owner to grant "+createRoles[permBucketCreate]+", or to create gs://"+bucket)
+	}
+	return pass(IDBucket, title, "gs://"+bucket+" (created on first publish)")
+}
+
+// missingPermissions returns the perms not granted and the sorted set of
+// roles that would grant them.
+func missingPermissions(perms, granted []string, roles map[string]string) (missing, need []string) {
+	have := map[string]bool{}
+	for _, g := range granted {
+		have[g] = true
+	}
+	seen := map[string]bool{}
+	for _, p := range perms {
+		if have[p] {
+			continue
+		}
+		missing = append(missing, p)
+		if r := roles[p]; r != "" && !seen[r] {
+			seen[r] = true
+			need = append(need, r)
+		}
+	}
+	sort.Strings(need)
+	return missing, need
+}
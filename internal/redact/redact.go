@@ -0,0 +1,110 @@
+// Package redact masks secrets in text that ends up on screen or in files:
+// error meta (API response bodies), cause messages and debug logs.
+package redact
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const Mask = "[REDACTED]"
+
+type rule struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+// Order matters: whole PEM blocks and JSON fields go first so the token
+// rules don't leave half-masked values behind.
+var rules = []rule{
+	// PEM private keys, raw or JSON-escaped ("\n" inside a string).
+	{regexp.MustCompile(`-----BEGIN ([A-Z ]*)PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+		"-----BEGIN ${1}PRIVATE KEY-----" + Mask + "-----END ${1}PRIVATE KEY-----"},
+	// JSON fields that only ever hold secrets.
+	{regexp.MustCompile(`("(?:access_token|refresh_token|id_token|client_secret|private_key|password|secret)"\s*:\s*")[^"]*(")`),
+		"${1}" + Mask + "${2}"},
+	// Same fields as form or query parameters.
+	{regexp.MustCompile(`((?:^|[?&\s])(?:access_token|refresh_token|id_token|client_secret)=)[^&\s"]+`),
+		"${1}" + Mask},
+	// OAuth authorization codes, only as a URL parameter ("exit code=1" stays).
+	{regexp.MustCompile(`([?&]code=)[^&\s"]+`), "${1}" + Mask},
+	// Authorization headers.
+	{regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`), "${1}" + Mask},
+	// Google OAuth access tokens and refresh tokens.
+	{regexp.MustCompile(`ya29\.[A-Za-z0-9._-]+`), "ya29." + Mask},
+	{regexp.MustCompile(`1//[A-Za-z0-9._-]{10,}`), "1//" + Mask},
+	// JWTs (ID tokens, signed assertions).
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`), Mask},
+	// Signed URL signatures (V4 and V2) and SAS-style sig params.
+	{regexp.MustCompile(`((?:X-Goog-Signature|Signature|sig)=)[^&\s"]+`), "${1}" + Mask},
+}
+
+// SecretEnv lists env vars whose values are always masked. Users add their
+// own with ADVNCD_REDACT_ENV (comma-separated names).
+var SecretEnv = []string{"ADVNCD_GCP_CLIENT_SECRET"}
+
+// Values shorter than this are not masked by value: too likely to match
+// ordinary words.
+const minSecretLen = 8
+
+var (
+	envOnce   sync.Once
+	envValues []string
+)
+
+func secretValues() []string {
+	envOnce.Do(func() {
+		names := append([]string{}, SecretEnv...)
+		for _, n := range strings.Split(os.Getenv("ADVNCD_REDACT_ENV"), ",") {
+			if n = strings.TrimSpace(n); n != "" {
+				names = append(names, n)
+			}
+		}
+		for _, n := range names {
+			if v := os.Getenv(n); len(v) >= minSecretLen {
+				envValues = append(envValues, v)
+			}
+		}
+		// Longest first, so a secret containing another is masked whole.
+		sort.Slice(envValues, func(i, j int) bool { return len(envValues[i]) > len(envValues[j]) })
+	})
+	return envValues
+}
+
+// String returns s with every known secret pattern and configured secret
+// value masked.
+func String(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, v := range secretValues() {
+		s = strings.ReplaceAll(s, v, Mask)
+	}
+	for _, r := range rules {
+		s = r.re.ReplaceAllString(s, r.repl)
+	}
+	return s
+}
+
+// MaxBody is how much of a long value (typically an API response body) is
+// shown before truncation.
+const MaxBody = 2048
+
+// Truncate shortens s to MaxBody bytes, cutting on a rune boundary and
+// noting how much was dropped.
+func Truncate(s string) (string, bool) {
+	if len(s) <= MaxBody {
+		return s, false
+	}
+	cut := MaxBody
+	for cut > 0 && !utf8RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + fmt.Sprintf("… [%d more bytes]", len(s)-cut), true
+}
+
+func utf8RuneStart(b byte) bool { return b&0xC0 != 0x80 }
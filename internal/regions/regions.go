@@ -0,0 +1,196 @@
+package regions
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/cache"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcpartifact"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/gcprun"
+)
+
+var (
+	ErrRegionInvalid = apperr.E("B-REGION-001", "Invalid region",
+		apperr.WithSummary("The region is not recognized or not available for Cloud Run in this project."))
+)
+
+// cacheTTL: the region list changes a few times a year.
+const cacheTTL = 24 * time.Hour
+
+type Region struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (r Region) Label() string {
+	if r.Name == "" || r.Name == r.ID {
+		return r.ID
+	}
+	return r.ID + " (" + r.Name + ")"
+}
+
+// Common is shown when the live list can't be fetched (offline, API disabled).
+var Common = []Region{
+	{"europe-west1", "Belgium"},
+	{"europe-west3", "Frankfurt"},
+	{"europe-west4", "Netherlands"},
+	{"europe-west6", "Zurich"},
+	{"us-central1", "Iowa"},
+	{"us-east1", "South Carolina"},
+	{"us-west1", "Oregon"},
+	{"asia-northeast1", "Tokyo"},
+	{"asia-southeast1", "Singapore"},
+}
+
+// List returns regions usable by `advncd publish` for the project: Cloud Run
+// locations that also host Artifact Registry. Cloud Build has no locations
+// API; its regional endpoints cover every Cloud Run region, so builds run in
+// the same region as the image repository.
+//
+// Results are cached per project for a day, once Artifact Registry
+// locations could be listed.
+func List(ctx context.Context, accessToken, projectID string) ([]Region, error) {
+	key := "regions-" + projectID
+
+	var cached []Region
+	if cache.Load(key, cacheTTL, &cached) && len(cached) > 0 {
+		return cached, nil
+	}
+
+	runLocs, err := gcprun.ListLocations(ctx, accessToken, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Artifact Registry may not be enabled yet (fresh project); don't let that
+	// block region selection, publish will report the missing API.
+	var arSet map[string]bool
+	if arLocs, err := gcpartifact.ListLocations(ctx, accessToken, projectID); err == nil {
+		arSet = map[string]bool{}
+		for _, id := range arLocs {
+			arSet[id] = true
+		}
+	}
+
+	var out []Region
+	for _, l := range runLocs {
+		if arSet != nil && !arSet[l.LocationID] {
+			continue
+		}
+		out = append(out, Region{ID: l.LocationID, Name: l.DisplayName})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+
+	// Unfiltered, the list is a stopgap until Artifact Registry answers:
+	// don't keep it for a day.
+	if arSet != nil {
+		_ = cache.Save(key, out)
+	}
+	return out, nil
+}
+
+// Find returns the region with this exact ID.
+func Find(available []Region, id string) (Region, bool) {
+	for _, r := range available {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Region{}, false
+}
+
+var idPattern = regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+$`)
+
+// LooksLikeID reports whether s has the shape of a GCP region ID (europe-west1).
+func LooksLikeID(s string) bool {
+	return idPattern.MatchString(s)
+}
+
+// Filter returns regions whose ID or name contains query (case-insensitive).
+func Filter(available []Region, query string) []Region {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return available
+	}
+	var out []Region
+	for _, r := range available {
+		if strings.Contains(r.ID, q) || strings.Contains(strings.ToLower(r.Name), q) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Validate checks id against the available list and returns an
+// ErrRegionInvalid error with close matches as fixes.
+func Validate(id string, available []Region) error {
+	if _, ok := Find(available, id); ok {
+		return nil
+	}
+
+	ae := apperr.New(ErrRegionInvalid).WithMeta("region", id)
+	if s := Suggest(id, available, 3); len(s) > 0 {
+		ae = ae.WithFix("Did you mean: " + strings.Join(s, ", ") + "?")
+	}
+	return ae.
+		WithFix("Pick a region interactively: advncd init").
+		WithFix("Or set it directly: advncd init --region europe-west1")
+}
+
+// Suggest returns up to n region IDs closest to id by edit distance.
+func Suggest(id string, available []Region, n int) []string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if id == "" {
+		return nil
+	}
+
+	type scored struct {
+		id   string
+		dist int
+	}
+	var all []scored
+	for _, r := range available {
+		d := levenshtein(id, r.ID)
+		// substring hits ("west1", "frankfurt") are good suggestions too
+		if strings.Contains(r.ID, id) || strings.Contains(strings.ToLower(r.Name), id) {
+			d = 0
+		}
+		all = append(all, scored{r.ID, d})
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].dist < all[j].dist })
+
+	// Only suggest reasonably close matches.
+	limit := len(id)/4 + 1
+	var out []string
+	for _, s := range all {
+		if s.dist > limit || len(out) == n {
+			break
+		}
+		out = append(out, s.id)
+	}
+	return out
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
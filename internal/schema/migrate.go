@@ -0,0 +1,166 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// Doc is a decoded JSON document (config.json, credentials.json) in its
+// generic form, so migrations can reshape it without knowing old Go types.
+type Doc map[string]any
+
+// Migration upgrades a document from version From to From+1.
+type Migration struct {
+	From  int
+	Apply func(doc Doc) error
+}
+
+// Plan describes how to bring a file up to Current.
+type Plan struct {
+	Current    int
+	Migrations []Migration
+}
+
+// TooNewError is returned when a file was written by a newer advncd.
+// We never downgrade: older code would silently drop fields it doesn't know.
+type TooNewError struct {
+	Found     int
+	Supported int
+}
+
+func (e *TooNewError) Error() string {
+	return fmt.Sprintf("schema version %d is newer than supported version %d", e.Found, e.Supported)
+}
+
+// Version reads the "version" field. Missing or zero means version 1
+// (early files were written without it).
+func Version(doc Doc) int {
+	v, ok := doc["version"].(float64)
+	if !ok || v < 1 {
+		return 1
+	}
+	return int(v)
+}
+
+// Decode parses raw JSON into a Doc.
+func Decode(b []byte) (Doc, error) {
+	var doc Doc
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		doc = Doc{}
+	}
+	return doc, nil
+}
+
+// Upgrade applies migrations in order until the document reaches p.Current.
+// Returns the version the document had before upgrading.
+func (p Plan) Upgrade(doc Doc) (int, error) {
+	from := Version(doc)
+	if from > p.Current {
+		return from, &TooNewError{Found: from, Supported: p.Current}
+	}
+
+	for v := from; v < p.Current; v++ {
+		m, ok := p.find(v)
+		if !ok {
+			return from, fmt.Errorf("no migration from schema version %d", v)
+		}
+		if err := m.Apply(doc); err != nil {
+			return from, fmt.Errorf("migrate v%d -> v%d: %w", v, v+1, err)
+		}
+		doc["version"] = v + 1
+	}
+	return from, nil
+}
+
+// Changed reports whether an upgrade altered the document beyond its
+// "version" field.
+func Changed(before, after Doc) bool {
+	strip := func(d Doc) Doc {
+		out := Doc{}
+		for k, v := range d {
+			if k != "version" {
+				out[k] = v
+			}
+		}
+		return out
+	}
+	return !reflect.DeepEqual(strip(before), strip(after))
+}
+
+func (p Plan) find(from int) (Migration, bool) {
+	for _, m := range p.Migrations {
+		if m.From == from {
+			return m, true
+		}
+	}
+	return Migration{}, false
+}
+
+// Backup copies the original bytes next to path as "<path>.v<version>.bak"
+// with owner-only permissions (credentials carry secrets).
+func Backup(path string, original []byte, version int) (string, error) {
+	dst := fmt.Sprintf("%s.v%d.bak", path, version)
+	if err := os.WriteFile(dst, original, 0o600); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// Section returns doc[key] as a nested object, or nil.
+func Section(doc Doc, key string) map[string]any {
+	m, _ := doc[key].(map[string]any)
+	return m
+}
+
+// Move copies src[from] into doc[to] if present and doc[to] is not set yet.
+func Move(doc Doc, src map[string]any, from, to string) {
+	if src == nil {
+		return
+	}
+	v, ok := src[from]
+	if !ok || v == nil {
+		return
+	}
+	if _, exists := doc[to]; exists {
+		return
+	}
+	doc[to] = v
+}
+
+// ReadFirst reads path, falling back to legacy (if set) when path does not
+// exist. Returns the bytes and the path they came from; os.ErrNotExist when
+// neither file exists.
+func ReadFirst(path, legacy string) ([]byte, string, error) {
+	b, err := os.ReadFile(path)
+	if err == nil || !os.IsNotExist(err) || legacy == "" {
+		return b, path, err
+	}
+	b, lerr := os.ReadFile(legacy)
+	if lerr != nil {
+		if os.IsNotExist(lerr) {
+			return nil, path, err
+		}
+		return nil, legacy, lerr
+	}
+	return b, legacy, nil
+}
+
+// Reencode converts an upgraded Doc into out via JSON.
+func Reencode(doc Doc, out any) error {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+// Retire renames an imported legacy file to "<path>.migrated" so it is kept
+// as a backup but never imported again (e.g. after logout).
+func Retire(path string) error {
+	return os.Rename(path, path+".migrated")
+}
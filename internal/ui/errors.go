@@ -0,0 +1,242 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/redact"
+)
+
+// Error output formats for the global --error-format flag.
+const (
+	ErrorFormatText = "text"
+	ErrorFormatJSON = "json"
+)
+
+var ErrorFormat = ErrorFormatText
+
+// Errors always go to stderr so stdout stays clean for results.
+var errOut = os.Stderr
+
+// DumpDir (--dump-dir) receives the full text of meta values too long to
+// print, typically API response bodies. Printed values are truncated
+// either way; secrets are masked in both.
+var DumpDir string
+
+// PrintError prints err with its cause chain as a tree. The headline is the
+// outermost apperr; Meta of every apperr in the chain is shown on its node,
+// and fixes from the whole chain are merged, outermost first.
+func PrintError(err error) {
+	if ErrorFormat == ErrorFormatJSON {
+		printErrorJSON(err)
+		return
+	}
+	head, ok := apperr.As(err)
+	if !ok {
+		PrintPlainError(err)
+		return
+	}
+	chain := apperr.Chain(err)
+
+	// Context added by fmt.Errorf("...: %w") above the apperr.
+	msg := i18n.Message(head.Code, head.Message)
+	if c := wrapContext(chain, head); c != "" {
+		fmt.Fprintf(errOut, "%s %s: %s: %s\n", i18n.T("Error"), head.Code, redact.String(c), msg)
+	} else {
+		fmt.Fprintf(errOut, "%s %s: %s\n", i18n.T("Error"), head.Code, msg)
+	}
+	if entry, ok := apperr.Lookup(head.Code); ok && entry.Summary != "" {
+		fmt.Fprintf(errOut, "  %s\n", i18n.Entry(entry).Summary)
+	}
+	printMeta(i18n.T("Details:"), "  ", displayMeta(head.Code, head.Meta))
+
+	// Causes below the headline.
+	var causes []error
+	seen := false
+	for _, e := range chain {
+		if e == error(head) {
+			seen = true
+			continue
+		}
+		if seen {
+			causes = append(causes, e)
+		}
+	}
+	if head.Cause != nil {
+		fmt.Fprintln(errOut)
+		fmt.Fprintln(errOut, i18n.T("Caused by:"))
+		for i, c := range causes {
+			indent := strings.Repeat("   ", i)
+			if ae, ok := c.(*apperr.Error); ok {
+				fmt.Fprintf(errOut, "  %s└─ %s: %s\n", indent, ae.Code, i18n.Message(ae.Code, ae.Message))
+				printMeta("", "  "+indent+"     ", displayMeta(ae.Code, ae.Meta))
+				continue
+			}
+			// Plain errors print their whole chain in Error(); show the
+			// part this node adds and let the next node show the rest.
+			fmt.Fprintf(errOut, "  %s└─ %s\n", indent, redact.String(ownText(c, causes[i+1:])))
+		}
+	}
+
+	if fixes := chainFixes(chain); len(fixes) > 0 {
+		fmt.Fprintln(errOut)
+		fmt.Fprintln(errOut, i18n.T("Fix:"))
+		for _, f := range fixes {
+			fmt.Fprintf(errOut, "  - %s\n", i18n.Text(f))
+		}
+	}
+}
+
+func printMeta(title, indent string, meta map[string]string) {
+	if len(meta) == 0 {
+		return
+	}
+	if title != "" {
+		fmt.Fprintln(errOut)
+		fmt.Fprintln(errOut, title)
+	}
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(errOut, "%s- %s: %s\n", indent, k, meta[k])
+	}
+}
+
+// displayMeta returns meta ready to print: secrets masked (again, in case
+// Meta was filled directly rather than via WithMeta) and long values
+// truncated. With DumpDir set, the full value is saved and referenced by a
+// "<key>_file" entry.
+func displayMeta(code string, meta map[string]string) map[string]string {
+	out := make(map[string]string, len(meta))
+	for k, v := range meta {
+		v = redact.String(v)
+		short, cut := redact.Truncate(v)
+		out[k] = short
+		if cut && DumpDir != "" {
+			if path, err := dumpValue(code, k, v); err == nil {
+				out[k+"_file"] = path
+			} else {
+				out[k+"_file"] = "(not saved: " + err.Error() + ")"
+			}
+		}
+	}
+	return out
+}
+
+// dumpValue writes v to DumpDir, readable only by the user.
+func dumpValue(code, key, v string) (string, error) {
+	if err := os.MkdirAll(DumpDir, 0o700); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s-%s.txt", code, key, time.Now().Format("20060102-150405"))
+	path := filepath.Join(DumpDir, name)
+	if err := os.WriteFile(path, []byte(v), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// wrapContext returns the text fmt.Errorf wrappers added above head.
+func wrapContext(chain []error, head *apperr.Error) string {
+	if len(chain) == 0 || chain[0] == error(head) {
+		return ""
+	}
+	return strings.TrimSuffix(chain[0].Error(), ": "+head.Error())
+}
+
+// ownText strips the text of the next cause from a wrapping error's message.
+func ownText(err error, rest []error) string {
+	s := err.Error()
+	if len(rest) > 0 {
+		if t := strings.TrimSuffix(s, ": "+rest[0].Error()); t != s {
+			return t
+		}
+	}
+	return s
+}
+
+func PrintPlainError(err error) {
+	fmt.Fprintf(errOut, "%s: %s\n", i18n.T("Error"), redact.String(err.Error()))
+}
+
+// chainFixes merges FixWith of every apperr in the chain, outermost first.
+func chainFixes(chain []error) []string {
+	var fixes []string
+	have := map[string]bool{}
+	for _, e := range chain {
+		if ae, ok := e.(*apperr.Error); ok {
+			for _, f := range ae.FixWith {
+				if !have[f] {
+					have[f] = true
+					fixes = append(fixes, f)
+				}
+			}
+		}
+	}
+	return fixes
+}
+
+// errorJSON is the --error-format json document, one per failed command.
+// It is not translated: scripts match on it.
+type errorJSON struct {
+	Code     string            `json:"code,omitempty"`
+	Message  string            `json:"message"`
+	Summary  string            `json:"summary,omitempty"`
+	ExitCode int               `json:"exit_code"`
+	Meta     map[string]string `json:"meta,omitempty"`
+	Fixes    []string          `json:"fixes,omitempty"`
+	Causes   []causeJSON       `json:"causes,omitempty"`
+}
+
+type causeJSON struct {
+	Code    string            `json:"code,omitempty"`
+	Message string            `json:"message"`
+	Meta    map[string]string `json:"meta,omitempty"`
+}
+
+func printErrorJSON(err error) {
+	out := errorJSON{Message: redact.String(err.Error()), ExitCode: apperr.ExitCode(err)}
+	chain := apperr.Chain(err)
+	if head, ok := apperr.As(err); ok {
+		out.Code = head.Code
+		out.Message = head.Message
+		if entry, ok := apperr.Lookup(head.Code); ok {
+			out.Summary = entry.Summary
+		}
+		if len(head.Meta) > 0 {
+			out.Meta = displayMeta(head.Code, head.Meta)
+		}
+		seen := false
+		for i, e := range chain {
+			if e == error(head) {
+				seen = true
+				continue
+			}
+			if !seen {
+				continue
+			}
+			if ae, ok := e.(*apperr.Error); ok {
+				c := causeJSON{Code: ae.Code, Message: ae.Message}
+				if len(ae.Meta) > 0 {
+					c.Meta = displayMeta(ae.Code, ae.Meta)
+				}
+				out.Causes = append(out.Causes, c)
+			} else {
+				out.Causes = append(out.Causes, causeJSON{Message: redact.String(ownText(e, chain[i+1:]))})
+			}
+		}
+		out.Fixes = chainFixes(chain)
+	}
+	b, _ := json.Marshal(out)
+	fmt.Fprintln(errOut, string(b))
+}
@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+)
+
+var ErrUnknownFormat = apperr.E("B-CLI-001", "Unsupported output format",
+	apperr.WithSummary("The requested output format is not supported by this command."))
+
+// CheckFormat validates a --format value against the allowed set.
+func CheckFormat(format string, allowed ...string) error {
+	for _, a := range allowed {
+		if format == a {
+			return nil
+		}
+	}
+	return apperr.New(ErrUnknownFormat).
+		WithMeta("format", format).
+		WithFix("Use one of: " + strings.Join(allowed, ", "))
+}
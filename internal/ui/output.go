@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output modes for the global --output flag.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// Output is the current mode; set once through SetOutput.
+var Output = OutputTable
+
+// result is where Render writes: the real stdout. In machine modes os.Stdout
+// itself points at stderr, so progress lines and prompts printed with plain
+// fmt.Print* never mix with the document scripts parse.
+var result = os.Stdout
+
+// SetOutput validates and applies an output mode.
+func SetOutput(mode string) error {
+	if err := CheckFormat(mode, OutputTable, OutputJSON, OutputYAML); err != nil {
+		return err
+	}
+	Output = mode
+	if Machine() {
+		os.Stdout = os.Stderr
+	}
+	return nil
+}
+
+// Machine reports whether output is for programs (json or yaml).
+func Machine() bool {
+	return Output == OutputJSON || Output == OutputYAML
+}
+
+// Render prints a command's result: human via the given printer in table
+// mode, otherwise v as JSON or YAML on stdout. JSON and YAML use the same
+// field names (the json tags), so one set of tags is the contract.
+func Render(v any, human func()) error {
+	return RenderAs(Output, v, human)
+}
+
+// RenderAs is Render with an explicit mode, for commands whose own flag
+// overrides --output.
+func RenderAs(mode string, v any, human func()) error {
+	switch mode {
+	case OutputJSON:
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(result, string(b))
+		return err
+	case OutputYAML:
+		b, err := toYAML(v)
+		if err != nil {
+			return err
+		}
+		_, err = result.Write(b)
+		return err
+	}
+	if human != nil {
+		// The result is never silenced, even by --quiet.
+		stdout := os.Stdout
+		os.Stdout = result
+		defer func() { os.Stdout = stdout }()
+		human()
+	}
+	return nil
+}
+
+// toYAML goes through JSON so field names and omitempty follow the json
+// tags, then drops the JSON quoting so the result reads like normal YAML.
+func toYAML(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	plain(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func plain(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		plain(c)
+	}
+}
@@ -0,0 +1,524 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+)
+
+var ErrPickCancelled = apperr.E("B-INPUT-003", "Selection cancelled",
+	apperr.WithSummary("The choice was cancelled with Esc or Ctrl-C."))
+
+// Choice is one item of a picker. Value is returned; Label is shown (the
+// value if empty) and Hint is dimmed after it. All three are searched.
+type Choice struct {
+	Value string
+	Label string
+	Hint  string
+}
+
+func (c Choice) label() string {
+	if c.Label != "" {
+		return c.Label
+	}
+	return c.Value
+}
+
+// PickOptions tunes Pick.
+type PickOptions struct {
+	// PageSize is how many rows are visible at once (default 10).
+	PageSize int
+	// Custom, if set, accepts typed text that matches no choice when it
+	// returns true, e.g. an ID that isn't in a truncated list.
+	Custom func(s string) bool
+	// Note is printed under the title, e.g. that the list is truncated.
+	Note string
+	// Search, if set, looks typed text up beyond the loaded choices, e.g.
+	// server-side. Enter runs it when nothing loaded matches, or whenever
+	// Partial is set; what it returns joins the choices.
+	Search func(query string) ([]Choice, error)
+	// Partial says the choices are only the start of a longer list, so a
+	// local match doesn't mean the search would find nothing more.
+	Partial bool
+}
+
+// Pick asks the user to choose one of choices. On a terminal it is a
+// full-screen-less picker: ↑/↓ move, PgUp/PgDn page, typing filters with
+// fuzzy matching, Enter picks, Esc cancels. Otherwise it falls back to a
+// numbered list read line by line. Callers check Interactive first.
+func Pick(title string, choices []Choice, o PickOptions) (string, error) {
+	if o.PageSize <= 0 {
+		o.PageSize = 10
+	}
+	out, ok := pickerTerminal()
+	if !ok {
+		return pickLines(title, choices, o)
+	}
+	p := &picker{title: title, all: choices, opts: o, out: out}
+	return p.run()
+}
+
+// pickerTerminal returns where to draw if both ends are a terminal that
+// understands cursor movement.
+func pickerTerminal() (*os.File, bool) {
+	out, _ := promptOut().(*os.File)
+	if out == nil || os.Getenv("TERM") == "dumb" {
+		return nil, false
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return nil, false
+	}
+	return out, true
+}
+
+type picker struct {
+	title  string
+	all    []Choice
+	opts   PickOptions
+	out    io.Writer
+	filter []rune
+	shown  []Choice
+	cursor int
+	drawn  int // lines drawn by the last frame
+	// searched holds the queries already sent to opts.Search.
+	searched map[string]bool
+	busy     string // shown instead of the footer while searching
+}
+
+func (p *picker) run() (string, error) {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return pickLines(p.title, p.all, p.opts)
+	}
+	fmt.Fprint(p.out, "\033[?25l")
+	defer func() {
+		p.clear()
+		fmt.Fprint(p.out, "\033[?25h")
+		_ = term.Restore(fd, state)
+	}()
+
+	p.refilter()
+	for {
+		p.draw()
+		k, r, err := readKey()
+		if err != nil {
+			return "", apperr.New(ErrInputClosed).WithCause(err)
+		}
+		switch k {
+		case keyCancel:
+			return "", apperr.New(ErrPickCancelled)
+		case keyEnter:
+			if q, ok := p.pendingSearch(); ok {
+				if err := p.search(q); err != nil {
+					return "", err
+				}
+				continue
+			}
+			if v, ok := p.selected(); ok {
+				p.clear()
+				fmt.Fprintf(p.out, "%s %s\r\n", p.title, v)
+				p.drawn = 0
+				return v, nil
+			}
+		case keyUp:
+			p.move(-1)
+		case keyDown:
+			p.move(1)
+		case keyPageUp:
+			p.move(-p.opts.PageSize)
+		case keyPageDown:
+			p.move(p.opts.PageSize)
+		case keyHome:
+			p.cursor = 0
+		case keyEnd:
+			p.cursor = len(p.shown) - 1
+		case keyBackspace:
+			if len(p.filter) > 0 {
+				p.filter = p.filter[:len(p.filter)-1]
+				p.refilter()
+			}
+		case keyClear:
+			p.filter = nil
+			p.refilter()
+		case keyRune:
+			p.filter = append(p.filter, r)
+			p.refilter()
+		}
+	}
+}
+
+// selected is the highlighted choice, or the typed text if nothing
+// matches and Custom accepts it.
+func (p *picker) selected() (string, bool) {
+	if len(p.shown) > 0 {
+		return p.shown[p.cursor].Value, true
+	}
+	s := strings.TrimSpace(string(p.filter))
+	if s != "" && p.opts.Custom != nil && p.opts.Custom(s) {
+		return s, true
+	}
+	return "", false
+}
+
+// pendingSearch returns the typed query if Enter should send it to
+// opts.Search rather than pick.
+func (p *picker) pendingSearch() (string, bool) {
+	q := strings.TrimSpace(string(p.filter))
+	if q == "" || p.opts.Search == nil || p.searched[q] {
+		return "", false
+	}
+	return q, len(p.shown) == 0 || p.opts.Partial
+}
+
+func (p *picker) search(q string) error {
+	p.busy = i18n.T("Searching %q...", q)
+	p.draw()
+	found, err := p.opts.Search(q)
+	p.busy = ""
+	if err != nil {
+		return err
+	}
+	if p.searched == nil {
+		p.searched = map[string]bool{}
+	}
+	p.searched[q] = true
+	p.all = mergeChoices(p.all, found)
+	p.refilter()
+	return nil
+}
+
+func (p *picker) move(d int) {
+	p.cursor += d
+	if p.cursor >= len(p.shown) {
+		p.cursor = len(p.shown) - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+}
+
+func (p *picker) refilter() {
+	p.shown = fuzzyFilter(p.all, string(p.filter))
+	p.cursor = 0
+}
+
+func (p *picker) clear() {
+	if p.drawn > 0 {
+		fmt.Fprintf(p.out, "\r\033[%dA\033[J", p.drawn)
+	} else {
+		fmt.Fprint(p.out, "\r\033[J")
+	}
+	p.drawn = 0
+}
+
+func (p *picker) draw() {
+	p.clear()
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s%s\r\n", p.title, colored("36", "> "), string(p.filter))
+	lines := 1
+	if p.opts.Note != "" {
+		b.WriteString(colored("2", "  "+p.opts.Note) + "\r\n")
+		lines++
+	}
+
+	page := p.cursor / p.opts.PageSize
+	from := page * p.opts.PageSize
+	to := from + p.opts.PageSize
+	if to > len(p.shown) {
+		to = len(p.shown)
+	}
+	for i := from; i < to; i++ {
+		c := p.shown[i]
+		row := c.label()
+		if c.Hint != "" {
+			row += "  " + colored("2", c.Hint)
+		}
+		if i == p.cursor {
+			b.WriteString(colored("36", "❯ ") + row + "\r\n")
+		} else {
+			b.WriteString("  " + row + "\r\n")
+		}
+		lines++
+	}
+	if len(p.shown) == 0 {
+		msg := i18n.T("(no matches)")
+		if s := strings.TrimSpace(string(p.filter)); s != "" && p.opts.Custom != nil && p.opts.Custom(s) {
+			msg = i18n.T("(no matches; Enter uses %q)", s)
+		}
+		b.WriteString("  " + colored("2", msg) + "\r\n")
+		lines++
+	}
+
+	pages := (len(p.shown) + p.opts.PageSize - 1) / p.opts.PageSize
+	footer := i18n.T("%d/%d · ↑↓ move · type to filter · Enter select · Esc cancel", len(p.shown), len(p.all))
+	if _, ok := p.pendingSearch(); ok {
+		footer = i18n.T("%d/%d · ↑↓ move · type to filter · Enter search · Esc cancel", len(p.shown), len(p.all))
+	}
+	if pages > 1 {
+		footer = i18n.T("page %d/%d", page+1, pages) + " · " + footer
+	}
+	if p.busy != "" {
+		footer = p.busy
+	}
+	b.WriteString(colored("2", "  "+footer))
+	fmt.Fprint(p.out, b.String())
+	// The cursor stays on the footer line; clear() moves up from there.
+	p.drawn = lines
+}
+
+type key int
+
+const (
+	keyRune key = iota
+	keyEnter
+	keyCancel
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyBackspace
+	keyClear
+	keyIgnore
+)
+
+// readKey decodes one key press from stdin in raw mode.
+func readKey() (key, rune, error) {
+	r, _, err := stdin.ReadRune()
+	if err != nil {
+		return 0, 0, err
+	}
+	switch r {
+	case '\r', '\n':
+		return keyEnter, 0, nil
+	case 3, 4: // Ctrl-C, Ctrl-D
+		return keyCancel, 0, nil
+	case 127, 8:
+		return keyBackspace, 0, nil
+	case 21: // Ctrl-U
+		return keyClear, 0, nil
+	case 16: // Ctrl-P
+		return keyUp, 0, nil
+	case 14: // Ctrl-N
+		return keyDown, 0, nil
+	case 27:
+		// A lone Esc cancels; escape sequences arrive in one read.
+		if stdin.Buffered() == 0 {
+			return keyCancel, 0, nil
+		}
+		return readEscape()
+	}
+	if unicode.IsPrint(r) {
+		return keyRune, r, nil
+	}
+	return keyIgnore, 0, nil
+}
+
+func readEscape() (key, rune, error) {
+	b, err := stdin.ReadByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	if b != '[' && b != 'O' {
+		return keyIgnore, 0, nil
+	}
+	var seq []byte
+	for {
+		c, err := stdin.ReadByte()
+		if err != nil {
+			return 0, 0, err
+		}
+		seq = append(seq, c)
+		if c >= 0x40 && c <= 0x7e {
+			break
+		}
+	}
+	switch string(seq) {
+	case "A":
+		return keyUp, 0, nil
+	case "B":
+		return keyDown, 0, nil
+	case "5~":
+		return keyPageUp, 0, nil
+	case "6~":
+		return keyPageDown, 0, nil
+	case "H", "1~":
+		return keyHome, 0, nil
+	case "F", "4~":
+		return keyEnd, 0, nil
+	}
+	return keyIgnore, 0, nil
+}
+
+// pickLines is the fallback without a terminal: a numbered list; the
+// answer is a number, an exact value, or text that narrows the list.
+func pickLines(title string, choices []Choice, o PickOptions) (string, error) {
+	limit := 2 * o.PageSize
+	shown := choices
+	searched := map[string]bool{}
+	fmt.Fprintln(promptOut())
+	fmt.Fprintln(promptOut(), title)
+	if o.Note != "" {
+		fmt.Fprintln(promptOut(), "  "+o.Note)
+	}
+	for {
+		if len(shown) == 0 {
+			fmt.Fprintln(promptOut(), "  "+i18n.T("(no matches)"))
+		}
+		for i, c := range shown {
+			if i == limit {
+				fmt.Fprintln(promptOut(), "  "+i18n.T("(showing first %d of %d; type text to filter)", limit, len(shown)))
+				break
+			}
+			row := c.label()
+			if c.Hint != "" {
+				row += "  — " + c.Hint
+			}
+			fmt.Fprintf(promptOut(), "  [%d] %s\n", i+1, row)
+		}
+
+		s, err := ReadLine(i18n.T("Enter number, value, or text to filter: "))
+		if err != nil {
+			return "", err
+		}
+		if s == "" {
+			shown = choices
+			continue
+		}
+		if n, err := strconv.Atoi(s); err == nil {
+			if n < 1 || n > len(shown) || n > limit {
+				fmt.Fprintln(promptOut(), i18n.T("Invalid choice."))
+				continue
+			}
+			return shown[n-1].Value, nil
+		}
+		for _, c := range choices {
+			if c.Value == s {
+				return s, nil
+			}
+		}
+		matches := fuzzyFilter(choices, s)
+		if o.Search != nil && !searched[s] && (len(matches) == 0 || o.Partial) {
+			found, err := o.Search(s)
+			if err != nil {
+				return "", err
+			}
+			searched[s] = true
+			choices = mergeChoices(choices, found)
+			matches = fuzzyFilter(choices, s)
+		}
+		switch {
+		case len(matches) == 1:
+			return matches[0].Value, nil
+		case len(matches) == 0 && o.Custom != nil && o.Custom(s):
+			return s, nil
+		}
+		shown = matches
+	}
+}
+
+// mergeChoices returns all followed by the choices of more not in it.
+func mergeChoices(all, more []Choice) []Choice {
+	out := append([]Choice(nil), all...)
+	seen := make(map[string]bool, len(out))
+	for _, c := range out {
+		seen[c.Value] = true
+	}
+	for _, c := range more {
+		if !seen[c.Value] {
+			seen[c.Value] = true
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// fuzzyFilter keeps the choices whose text contains query's characters in
+// order (case-insensitive), best matches first; ties keep input order.
+func fuzzyFilter(choices []Choice, query string) []Choice {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return choices
+	}
+	type scored struct {
+		c     Choice
+		score int
+		i     int
+	}
+	var hits []scored
+	for i, c := range choices {
+		best := -1
+		for _, text := range []string{c.Value, c.label(), c.Hint} {
+			if s := fuzzyScore(strings.ToLower(text), query); s > best {
+				best = s
+			}
+		}
+		if best >= 0 {
+			hits = append(hits, scored{c, best, i})
+		}
+	}
+	sort.SliceStable(hits, func(a, b int) bool { return hits[a].score > hits[b].score })
+	out := make([]Choice, len(hits))
+	for i, h := range hits {
+		out[i] = h.c
+	}
+	return out
+}
+
+// fuzzyScore returns -1 if query is not a subsequence of text, otherwise a
+// score of 0 or more that rewards contiguous runs, word starts and an
+// early first hit.
+func fuzzyScore(text, query string) int {
+	if strings.Contains(text, query) {
+		score := 100 + 2*utf8.RuneCountInString(query)
+		if strings.HasPrefix(text, query) {
+			score += 50
+		}
+		return score
+	}
+	score, ti, prev := 0, 0, -2
+	tr := []rune(text)
+	for _, q := range query {
+		found := false
+		for ; ti < len(tr); ti++ {
+			if tr[ti] != q {
+				continue
+			}
+			switch {
+			case ti == prev+1:
+				score += 5
+			case ti == 0 || strings.ContainsRune(" -_./", tr[ti-1]):
+				score += 3
+			default:
+				score++
+			}
+			if prev == -2 {
+				score -= ti / 4
+			}
+			prev = ti
+			ti++
+			found = true
+			break
+		}
+		if !found {
+			return -1
+		}
+	}
+	// A late first hit lowers the score, but a match is still a match.
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
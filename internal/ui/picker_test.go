@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	cases := []struct {
+		text, query string
+		match       bool
+	}{
+		{"prod-api", "prod", true},
+		{"prod-api", "pa", true},
+		{"prod-api", "ip", false},
+		{"prod-api", "x", false},
+		// The first hit is far in: the penalty must not turn it into a miss.
+		{strings.Repeat("a", 60) + "xbby", "xy", true},
+	}
+	for _, c := range cases {
+		got := fuzzyScore(c.text, c.query)
+		if c.match && got < 0 || !c.match && got != -1 {
+			t.Errorf("fuzzyScore(%q, %q) = %d, want match=%v", c.text, c.query, got, c.match)
+		}
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	choices := []Choice{
+		{Value: "alpha-prod"},
+		{Value: "beta", Hint: "org / team-prod"},
+		{Value: "gamma"},
+		{Value: strings.Repeat("a", 80) + "-p-r-o-d"},
+	}
+	got := fuzzyFilter(choices, "PROD")
+	want := []string{"alpha-prod", "beta", strings.Repeat("a", 80) + "-p-r-o-d"}
+	if len(got) != len(want) {
+		t.Fatalf("fuzzyFilter = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].Value != want[i] {
+			t.Errorf("fuzzyFilter[%d] = %q, want %q", i, got[i].Value, want[i])
+		}
+	}
+}
+
+func TestMergeChoices(t *testing.T) {
+	all := []Choice{{Value: "a"}, {Value: "b"}}
+	got := mergeChoices(all[:1], []Choice{{Value: "a", Label: "dup"}, {Value: "c"}})
+	if len(got) != 2 || got[0].Label != "" || got[1].Value != "c" {
+		t.Errorf("mergeChoices = %v", got)
+	}
+	if all[1].Value != "b" {
+		t.Errorf("mergeChoices wrote into the caller's slice: %v", all)
+	}
+}
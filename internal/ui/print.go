@@ -2,9 +2,8 @@ package ui
 
 import (
 	"fmt"
-	"sort"
 
-	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
 )
 
 type LoginInstructions struct {
@@ -15,47 +14,18 @@ type LoginInstructions struct {
 }
 
 func PrintLoginInstructions(in LoginInstructions) {
-	fmt.Println("To authenticate, visit this URL in your browser:")
+	fmt.Println(i18n.T("To authenticate, visit this URL in your browser:"))
 	fmt.Printf("  %s\n", in.VerificationURL)
 	fmt.Println()
-	fmt.Println("Then enter this code:")
+	fmt.Println(i18n.T("Then enter this code:"))
 	fmt.Printf("  %s\n", in.UserCode)
 	fmt.Println()
-	fmt.Printf("Code expires in %d seconds. Poll interval: %d seconds.\n", in.ExpiresIn, in.Interval)
-}
-
-func PrintError(e *apperr.Error) {
-	fmt.Printf("Error %s: %s\n", e.Code, e.Message)
-
-	if len(e.Meta) > 0 {
-		fmt.Println()
-		fmt.Println("Details:")
-		keys := make([]string, 0, len(e.Meta))
-		for k := range e.Meta {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		for _, k := range keys {
-			fmt.Printf("  - %s: %s\n", k, e.Meta[k])
-		}
-	}
-
-	if len(e.FixWith) > 0 {
-		fmt.Println()
-		fmt.Println("Fix:")
-		for _, f := range e.FixWith {
-			fmt.Printf("  - %s\n", f)
-		}
-	}
-}
-
-func PrintPlainError(err error) {
-	fmt.Printf("Error: %v\n", err)
+	fmt.Println(i18n.T("Code expires in %d seconds. Poll interval: %d seconds.", in.ExpiresIn, in.Interval))
 }
 
 func PrintAuthCodeReceived(redirectURI, listenAddr string) {
 	fmt.Println()
-	fmt.Println("✓ Authorization callback received")
+	fmt.Println("✓ " + i18n.T("Authorization callback received"))
 	fmt.Printf("  Redirect URI: %s\n", redirectURI)
 	fmt.Printf("  Listener:     %s\n", listenAddr)
 }
\ No newline at end of file
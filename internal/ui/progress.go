@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/debug"
+)
+
+// Quiet (global --quiet) hides progress and informational lines; results,
+// prompts and errors still print. Verbose (--verbose) adds step details
+// and the debug log.
+var (
+	Quiet   bool
+	Verbose bool
+)
+
+// SetVerbosity applies --quiet / --verbose. Call after SetOutput: in quiet
+// mode stdout goes nowhere until Render prints the result.
+func SetVerbosity(quiet, verbose bool) {
+	Quiet, Verbose = quiet, verbose
+	if Verbose {
+		debug.Enabled = true
+	}
+	if Quiet {
+		os.Stdout = devNull()
+	}
+}
+
+func devNull() *os.File {
+	f, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return os.Stderr
+	}
+	return f
+}
+
+// promptOut is where prompts go: stdout normally (stderr in machine
+// modes), stderr when quiet has silenced stdout.
+func promptOut() io.Writer {
+	if Quiet {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// live reports whether progress can redraw in place: a terminal, and
+// neither NO_COLOR nor TERM=dumb asking for plain output.
+func live() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+func colored(code, s string) string {
+	if !live() {
+		return s
+	}
+	return "\033[" + code + "m" + s + "\033[0m"
+}
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Step is one stage of a longer operation. On a terminal it shows a
+// spinner with elapsed time and the latest status; otherwise it prints a
+// start line and a result line. Finish it with Done or Fail.
+type Step struct {
+	title string
+	start time.Time
+	live  bool
+
+	mu     sync.Mutex
+	status string
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// StartStep begins a step titled title (already translated).
+func StartStep(title string) *Step {
+	s := &Step{title: title, start: time.Now(), live: !Quiet && live()}
+	switch {
+	case Quiet:
+	case s.live:
+		s.stop = make(chan struct{})
+		s.wg.Add(1)
+		go s.spin()
+	default:
+		fmt.Printf("… %s\n", title)
+	}
+	return s
+}
+
+// RunStep runs fn as a step, finishing it with fn's result.
+func RunStep(title string, fn func(s *Step) error) error {
+	s := StartStep(title)
+	if err := fn(s); err != nil {
+		s.Fail()
+		return err
+	}
+	s.Done("")
+	return nil
+}
+
+// Status sets the short state shown next to the title (e.g. a build's
+// QUEUED / WORKING). Without a terminal, changes print as lines.
+func (s *Step) Status(status string) {
+	s.mu.Lock()
+	changed := status != s.status
+	s.status = status
+	s.mu.Unlock()
+	if changed && !s.live && !Quiet && status != "" {
+		fmt.Printf("  %s: %s (%s)\n", s.title, status, elapsed(time.Since(s.start)))
+	}
+}
+
+// Logf prints a detail line under the step in --verbose mode.
+func (s *Step) Logf(format string, args ...any) {
+	if !Verbose || Quiet {
+		return
+	}
+	s.println("    " + fmt.Sprintf(format, args...))
+}
+
+// Warnf prints a warning under the step unless --quiet.
+func (s *Step) Warnf(format string, args ...any) {
+	if Quiet {
+		return
+	}
+	s.println("    " + colored("33", "⚠ "+fmt.Sprintf(format, args...)))
+}
+
+// println prints line under the step, keeping the spinner below it.
+func (s *Step) println(line string) {
+	if s.live {
+		s.mu.Lock()
+		fmt.Print("\r\033[K" + line + "\n")
+		s.mu.Unlock()
+		return
+	}
+	fmt.Println(line)
+}
+
+// Done finishes the step with ✓ and an optional detail.
+func (s *Step) Done(detail string) {
+	s.finish(colored("32", "✓"), detail)
+}
+
+// Fail finishes the step with ✗; the error itself is printed by the caller.
+func (s *Step) Fail() {
+	s.finish(colored("31", "✗"), "")
+}
+
+func (s *Step) finish(mark, detail string) {
+	if s.stop != nil {
+		close(s.stop)
+		s.wg.Wait()
+		s.stop = nil
+		fmt.Print("\r\033[K")
+	}
+	if Quiet {
+		return
+	}
+	line := fmt.Sprintf("%s %s", mark, s.title)
+	if detail != "" {
+		line += ": " + detail
+	}
+	fmt.Printf("%s %s\n", line, colored("2", "("+elapsed(time.Since(s.start))+")"))
+}
+
+func (s *Step) spin() {
+	defer s.wg.Done()
+	t := time.NewTicker(100 * time.Millisecond)
+	defer t.Stop()
+	for i := 0; ; i++ {
+		s.mu.Lock()
+		line := fmt.Sprintf("%s %s", colored("36", spinnerFrames[i%len(spinnerFrames)]), s.title)
+		if s.status != "" {
+			line += " · " + s.status
+		}
+		fmt.Printf("\r\033[K%s %s", line, colored("2", elapsed(time.Since(s.start))))
+		s.mu.Unlock()
+		select {
+		case <-s.stop:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// elapsed formats a duration for progress: 0.4s, 12.3s, 2m05s.
+func elapsed(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	d = d.Truncate(time.Second)
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
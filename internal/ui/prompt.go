@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/apperr"
+	"github.com/ADVNCD-Cloud/advncd-cli/internal/i18n"
+)
+
+var (
+	ErrInputRequired = apperr.E("B-INPUT-001", "Interactive input required",
+		apperr.WithSummary("A value is missing and prompting is disabled or there is no terminal."))
+	ErrInputClosed = apperr.E("B-INPUT-002", "Input closed before a choice was made",
+		apperr.WithSummary("Standard input ended while advncd was waiting for an answer."))
+)
+
+// NonInteractive disables all prompts (global --non-interactive / --yes).
+// Commands must then get everything from flags or config, or fail fast.
+var NonInteractive bool
+
+// AssumeYes answers yes to confirmations (global --yes).
+var AssumeYes bool
+
+// One reader for the whole process: separate bufio.Readers on stdin would
+// each swallow buffered input meant for the next prompt.
+var stdin = bufio.NewReader(os.Stdin)
+
+// Interactive reports whether we may prompt: not disabled by flags and
+// stdin is a terminal (not a pipe, file or CI runner).
+func Interactive() bool {
+	return !NonInteractive && term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// InputRequired builds the error returned instead of prompting in
+// non-interactive mode. fixes should name the flags/commands that provide
+// the missing values.
+func InputRequired(what string, fixes ...string) *apperr.Error {
+	ae := apperr.New(ErrInputRequired).WithMeta("missing", what)
+	for _, f := range fixes {
+		ae = ae.WithFix(f)
+	}
+	return ae
+}
+
+// ReadLine prints prompt and returns the trimmed answer. EOF (closed stdin,
+// Ctrl-D) is an error, never an empty answer, so callers can't loop forever.
+func ReadLine(prompt string) (string, error) {
+	out := promptOut()
+	fmt.Fprint(out, prompt)
+	s, err := stdin.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && s != "") {
+		fmt.Fprintln(out)
+		return "", apperr.New(ErrInputClosed).WithCause(err).
+			WithFix("Pass the value with a flag, or run the command in an interactive terminal.")
+	}
+	return strings.TrimSpace(s), nil
+}
+
+// Confirm asks a yes/no question. --yes answers yes; without a terminal
+// (or with --non-interactive) the default is returned without asking.
+func Confirm(question string, def bool) (bool, error) {
+	if AssumeYes {
+		return true, nil
+	}
+	if !Interactive() {
+		return def, nil
+	}
+
+	hint := "[y/N]"
+	if def {
+		hint = "[Y/n]"
+	}
+	for {
+		s, err := ReadLine(fmt.Sprintf("%s %s: ", question, hint))
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(s) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(promptOut(), i18n.T("Please answer y or n."))
+	}
+}
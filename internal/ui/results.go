@@ -0,0 +1,162 @@
+package ui
+
+import "time"
+
+// Results printed with --output json|yaml. Field names are a contract for
+// scripts: add fields freely, never rename or remove one without a major
+// version bump. Empty optional fields are omitted.
+
+// StatusResult is printed by `advncd status`.
+type StatusResult struct {
+	Auth    AuthStatus     `json:"auth"`
+	Config  ConfigStatus   `json:"config"`
+	Billing *BillingStatus `json:"billing,omitempty"` // omitted when config is not set
+	APIs    []APIStatus    `json:"apis,omitempty"`    // omitted when the project can't be read
+	// MissingAPIs lists required APIs that are disabled.
+	MissingAPIs []string `json:"missing_apis,omitempty"`
+}
+
+type AuthStatus struct {
+	Email           string    `json:"email"`
+	TokenExpiresAt  time.Time `json:"token_expires_at"`
+	CredentialsPath string    `json:"credentials_path"`
+}
+
+type ConfigStatus struct {
+	Path          string `json:"path"`
+	Set           bool   `json:"set"` // project and region both set
+	ProjectID     string `json:"project_id,omitempty"`
+	ProjectNumber string `json:"project_number,omitempty"`
+	Region        string `json:"region,omitempty"`
+	// RegionValid is omitted when the region list couldn't be fetched.
+	RegionValid       *bool    `json:"region_valid,omitempty"`
+	RegionSuggestions []string `json:"region_suggestions,omitempty"`
+}
+
+// Billing and API states.
+const (
+	StateEnabled  = "enabled"
+	StateDisabled = "disabled"
+	StateUnknown  = "unknown"
+)
+
+type BillingStatus struct {
+	State   string   `json:"state"`             // enabled, disabled or unknown
+	Account string   `json:"account,omitempty"` // billing account ID when enabled
+	Fixes   []string `json:"fixes,omitempty"`
+}
+
+type APIStatus struct {
+	Name  string `json:"name"`  // e.g. run.googleapis.com
+	State string `json:"state"` // enabled, disabled or unknown
+}
+
+// ConfigResult is printed by commands that change the config:
+// `advncd init`, `gcp project set`, `gcp region set`.
+type ConfigResult struct {
+	ProjectID  string `json:"project_id,omitempty"`
+	Region     string `json:"region,omitempty"`
+	ConfigPath string `json:"config_path"`
+}
+
+// PublishResult is printed by `advncd publish`.
+type PublishResult struct {
+	ProjectID    string        `json:"project_id"`
+	Region       string        `json:"region"`
+	Service      string        `json:"service"`
+	Image        string        `json:"image"`
+	Builder      string        `json:"builder,omitempty"` // buildpacks or docker
+	BuildID      string        `json:"build_id"`
+	SourceDigest string        `json:"source_digest,omitempty"` // sha256:<hex> of the source archive
+	Buildable    string        `json:"buildable,omitempty"`     // package built, when not the source root
+	BuildLogURL  string        `json:"build_log_url,omitempty"`
+	Revision     string        `json:"revision,omitempty"`
+	URL          string        `json:"url,omitempty"`
+	Checks       []CheckResult `json:"checks"`
+}
+
+// DoctorResult is printed by `advncd doctor`.
+type DoctorResult struct {
+	OK     bool          `json:"ok"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// CheckResult is one readiness check of publish or doctor.
+type CheckResult struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Status string   `json:"status"` // pass, warn, fail or skip
+	Detail string   `json:"detail,omitempty"`
+	Fixes  []string `json:"fixes,omitempty"`
+}
+
+// APIsEnableResult is printed by `advncd apis enable`.
+type APIsEnableResult struct {
+	ProjectID string   `json:"project_id"`
+	Enabled   []string `json:"enabled"` // empty when everything was already on
+}
+
+// BillingLinkResult is printed by `advncd gcp billing link`.
+type BillingLinkResult struct {
+	ProjectID      string `json:"project_id"`
+	Account        string `json:"account"`
+	BillingEnabled bool   `json:"billing_enabled"`
+}
+
+// ProjectCreateResult is printed by `advncd gcp project create`. Steps
+// skipped for lack of a billing account or region are left empty/false.
+type ProjectCreateResult struct {
+	ProjectID      string   `json:"project_id"`
+	Parent         string   `json:"parent,omitempty"`
+	BillingAccount string   `json:"billing_account,omitempty"`
+	EnabledAPIs    []string `json:"enabled_apis,omitempty"`
+	Region         string   `json:"region,omitempty"`
+	Repository     string   `json:"repository,omitempty"`
+}
+
+// AccessTokenResult is printed by `advncd auth print-access-token`.
+type AccessTokenResult struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// ErrorEntry is one catalog entry, printed by `advncd errors list|explain`.
+type ErrorEntry struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Title    string `json:"title"`
+	Message  string `json:"message"`
+	Summary  string `json:"summary,omitempty"`
+	DocsHint string `json:"docs_hint,omitempty"`
+}
+
+// BuildResult is one Cloud Build, printed by `advncd builds get` and (as
+// an array) `advncd builds list`.
+type BuildResult struct {
+	ID         string   `json:"id"`
+	Status     string   `json:"status"`
+	CreateTime string   `json:"create_time,omitempty"`
+	FinishTime string   `json:"finish_time,omitempty"`
+	LogURL     string   `json:"log_url,omitempty"`
+	Images     []string `json:"images,omitempty"`
+}
+
+// SourceListResult is printed by `advncd publish --list-files`: what the
+// source archive would contain.
+type SourceListResult struct {
+	Dir         string       `json:"dir"`
+	Buildable   string       `json:"buildable,omitempty"`  // package built, relative to dir
+	Dockerfile  string       `json:"dockerfile,omitempty"` // for docker builds, relative to dir
+	IgnoreFiles []string     `json:"ignore_files,omitempty"`
+	Files       []SourceFile `json:"files"`
+	TotalSize   int64        `json:"total_size"`   // bytes before compression
+	ArchiveSize int64        `json:"archive_size"` // bytes of the .tar.gz
+	Digest      string       `json:"digest"`       // sha256:<hex> of the .tar.gz
+}
+
+// SourceFile is one entry of SourceListResult.
+type SourceFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	Link string `json:"link,omitempty"` // target, for symlinks
+}